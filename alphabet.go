@@ -1,8 +1,11 @@
 package shortuuid
 
 import (
-	"fmt"
+	"encoding/binary"
+	"errors"
+	"io"
 	"math"
+	"math/bits"
 	"slices"
 	"unicode/utf8"
 )
@@ -17,12 +20,31 @@ const (
 // sorted, deduplicated characters along with precomputed values for efficient
 // encoding and decoding.
 type alphabet struct {
-	chars    []rune  // sorted, deduplicated characters
-	len      int64   // number of characters in the alphabet
-	encLen   uint8   // maximum encoded length for a 128-bit value
-	maxBytes uint8   // maximum UTF-8 bytes needed for any character
+	chars    []rune // sorted, deduplicated characters
+	len      int64  // number of characters in the alphabet
+	encLen   uint8  // maximum encoded length for a 128-bit value
+	maxBytes uint8  // maximum UTF-8 bytes needed for any character
+	pow      uint64 // largest power of len that fits in a uint64
+	powN     int    // exponent of pow, i.e. the number of digits it holds
+
+	// Reverse lookup tables from character to index. ascii is used if every
+	// character is a single byte. Otherwise dense, which is indexed by the
+	// character minus denseMin, is used if the characters span a small enough
+	// range, and index if they don't.
+	ascii    *[256]byte
+	dense    []int32
+	denseMin rune
+	index    map[rune]int64
 }
 
+// maxDenseSpan is the largest range of characters that a dense reverse lookup
+// table is built for.
+const maxDenseSpan = 1 << 16
+
+// errAlphabetTooShort is returned when an alphabet has fewer than 2 unique
+// characters.
+var errAlphabetTooShort = errors.New("encoding alphabet must be at least two characters")
+
 // newAlphabet creates a new alphabet from the given string. Removes
 // duplicates and sorts the characters to ensure reproducibility.
 //
@@ -30,40 +52,117 @@ type alphabet struct {
 // characters. An alphabet must have at least 2 characters to be usable for
 // base-N encoding.
 func newAlphabet(s string) alphabet {
+	abc, err := parseAlphabet(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return abc
+}
+
+// parseAlphabet is like newAlphabet but returns an error instead of
+// panicking if the alphabet is invalid.
+func parseAlphabet(s string) (alphabet, error) {
 	abc := []rune(s)
 	slices.Sort(abc)
 	abc = slices.Compact(abc)
 
 	if len(abc) < 2 {
-		panic("encoding alphabet must be at least two characters")
+		return alphabet{}, errAlphabetTooShort
 	}
 
-	return alphabet{
+	a := alphabet{
 		chars:    abc,
 		len:      int64(len(abc)),
 		encLen:   uint8(math.Ceil(128 / math.Log2(float64(len(abc))))),
 		maxBytes: uint8(utf8.RuneLen(abc[len(abc)-1])),
 	}
+	a.pow, a.powN = maxPow(uint64(a.len))
+
+	if a.maxBytes == 1 {
+		// At most 128 characters, so 255 is free to mark absent ones.
+		a.ascii = new([256]byte)
+		for i := range a.ascii {
+			a.ascii[i] = 255
+		}
+		for i, c := range abc {
+			a.ascii[c] = byte(i)
+		}
+	} else if span := abc[len(abc)-1] - abc[0] + 1; span <= maxDenseSpan {
+		a.denseMin = abc[0]
+		a.dense = make([]int32, span)
+		for i := range a.dense {
+			a.dense[i] = -1
+		}
+		for i, c := range abc {
+			a.dense[c-a.denseMin] = int32(i)
+		}
+	} else {
+		a.index = make(map[rune]int64, len(abc))
+		for i, c := range abc {
+			a.index[c] = int64(i)
+		}
+	}
+	return a, nil
 }
 
 func (a *alphabet) Length() int64 {
 	return a.len
 }
 
-// Index returns the index of the first instance of t in the alphabet, or an
-// error if t is not present.
+// Index returns the index of the first instance of t in the alphabet, or
+// ErrInvalidChar if t is not present.
 func (a *alphabet) Index(t rune) (int64, error) {
-	i, j := 0, int(a.len)
-	for i < j {
-		h := int(uint(i+j) >> 1)
-		if a.chars[h] < t {
-			i = h + 1
-		} else {
-			j = h
+	if a.ascii != nil {
+		if t >= 0 && t < 256 && a.ascii[t] != 255 {
+			return int64(a.ascii[t]), nil
+		}
+		return 0, ErrInvalidChar
+	}
+	if a.dense != nil {
+		if i := uint(t - a.denseMin); i < uint(len(a.dense)) && a.dense[i] >= 0 {
+			return int64(a.dense[i]), nil
 		}
+		return 0, ErrInvalidChar
 	}
-	if i >= int(a.len) || a.chars[i] != t {
-		return 0, fmt.Errorf("element '%v' is not part of the alphabet", t)
+	if i, ok := a.index[t]; ok {
+		return i, nil
+	}
+	return 0, ErrInvalidChar
+}
+
+// randomString returns a string of length characters drawn uniformly from the
+// alphabet, using r as the source of randomness.
+//
+// Indexes are sampled by masking random bytes down to the smallest power of 2
+// that covers the alphabet and discarding values outside of it, which avoids
+// the bias a plain modulo would introduce. length must not be negative.
+func (a *alphabet) randomString(r io.Reader, length int) (string, error) {
+	mask := uint32(1)<<bits.Len32(uint32(a.len-1)) - 1
+	width := 1
+	if mask > math.MaxUint8 {
+		width = 4
+	}
+
+	var rnd [64]byte
+	buf := make([]byte, 0, length*int(a.maxBytes))
+	for n := 0; n < length; {
+		if _, err := io.ReadFull(r, rnd[:]); err != nil {
+			return "", err
+		}
+		for i := 0; i+width <= len(rnd) && n < length; i += width {
+			var v uint32
+			if width == 1 {
+				v = uint32(rnd[i])
+			} else {
+				v = binary.BigEndian.Uint32(rnd[i:])
+			}
+			v &= mask
+			if int64(v) >= a.len {
+				continue
+			}
+			buf = utf8.AppendRune(buf, a.chars[v])
+			n++
+		}
 	}
-	return int64(i), nil
+	return string(buf), nil
 }
@@ -1,6 +1,7 @@
 package shortuuid
 
 import (
+	"bytes"
 	"testing"
 )
 
@@ -29,7 +30,57 @@ func TestAlphabetIndexZero(t *testing.T) {
 func TestAlphabetIndexError(t *testing.T) {
 	abc := newAlphabet(DefaultAlphabet)
 	idx, err := abc.Index('l')
+	if err != ErrInvalidChar {
+		t.Errorf("expected ErrInvalidChar, got a valid index %d", idx)
+	}
+}
+
+func TestAlphabetIndexLookupTables(t *testing.T) {
+	tests := []struct {
+		abc     string
+		invalid []rune
+	}{
+		{"0123456789abcdef", []rune{'g', 'é', 'う', -1}},
+		{"うえおなにぬねのウエオナニヌネノ", []rune{'a', 'ア', '😀', -1}},
+		{"ab😀", []rune{'c', '😁', 'う', -1}},
+	}
+	for _, test := range tests {
+		abc := newAlphabet(test.abc)
+		for i, c := range abc.chars {
+			idx, err := abc.Index(c)
+			if err != nil {
+				t.Errorf("expected index %d for %q, got an error %v", i, c, err)
+			}
+			if idx != int64(i) {
+				t.Errorf("expected index %d for %q, got %d", i, c, idx)
+			}
+		}
+		for _, c := range test.invalid {
+			if idx, err := abc.Index(c); err != ErrInvalidChar {
+				t.Errorf("expected ErrInvalidChar for %q, got a valid index %d", c, idx)
+			}
+		}
+	}
+}
+
+func TestAlphabetRandomString(t *testing.T) {
+	abc := newAlphabet("abc")
+	// Index 3 is outside the alphabet and must be rejected rather than
+	// wrapped around to 'a'.
+	rnd := bytes.Repeat([]byte{3, 0, 7, 1, 2}, 13)
+	s, err := abc.randomString(bytes.NewReader(rnd), 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "abcabc" {
+		t.Errorf("expected %q, got %q", "abcabc", s)
+	}
+}
+
+func TestAlphabetRandomStringError(t *testing.T) {
+	abc := newAlphabet(DefaultAlphabet)
+	_, err := abc.randomString(bytes.NewReader(nil), 1)
 	if err == nil {
-		t.Errorf("expected an error, got a valid index %d", idx)
+		t.Error("expected an error from an exhausted reader")
 	}
 }
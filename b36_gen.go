@@ -0,0 +1,186 @@
+// Code generated by genencoder -name Base36 -type b36Encoder; DO NOT EDIT.
+
+package shortuuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/google/uuid"
+)
+
+// Base36Alphabet is the alphabet used by Base36Encoder.
+const Base36Alphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const (
+	b36MaxU64Digits  = 12
+	b36MaxU64Divisor = 4738381338321616896 // 36^12
+)
+
+var b36Alphabet = newAlphabet(Base36Alphabet)
+
+// b36Encoder is an unrolled encoder for Base36Alphabet, generated like
+// b57Encoder. Encoded UUIDs are always 25 characters long.
+type b36Encoder struct{}
+
+func (e b36Encoder) alpha() *alphabet {
+	return &b36Alphabet
+}
+
+func (e b36Encoder) Encode(u uuid.UUID) string {
+	var buf [25]byte
+	e.encode(&buf, u)
+	return unsafe.String(unsafe.SliceData(buf[:]), 25)
+}
+
+// AppendEncode appends the encoded form of u to dst and returns the extended
+// buffer. It does not allocate if dst has enough capacity.
+func (e b36Encoder) AppendEncode(dst []byte, u uuid.UUID) []byte {
+	var buf [25]byte
+	e.encode(&buf, u)
+	return append(dst, buf[:]...)
+}
+
+// EncodeTo writes the encoded form of u to dst and returns the number of bytes
+// written, which is always 25. It returns io.ErrShortBuffer if dst is too
+// small, in which case nothing is written.
+func (e b36Encoder) EncodeTo(dst []byte, u uuid.UUID) (int, error) {
+	if len(dst) < 25 {
+		return 0, io.ErrShortBuffer
+	}
+	e.encode((*[25]byte)(dst), u)
+	return 25, nil
+}
+
+func (e b36Encoder) encode(buf *[25]byte, u uuid.UUID) {
+	num := uint128{
+		binary.BigEndian.Uint64(u[8:]),
+		binary.BigEndian.Uint64(u[:8]),
+	}
+	var r uint64
+	num, r = num.quoRem64(b36MaxU64Divisor)
+	buf[24], r = Base36Alphabet[r%36], r/36
+	buf[23], r = Base36Alphabet[r%36], r/36
+	buf[22], r = Base36Alphabet[r%36], r/36
+	buf[21], r = Base36Alphabet[r%36], r/36
+	buf[20], r = Base36Alphabet[r%36], r/36
+	buf[19], r = Base36Alphabet[r%36], r/36
+	buf[18], r = Base36Alphabet[r%36], r/36
+	buf[17], r = Base36Alphabet[r%36], r/36
+	buf[16], r = Base36Alphabet[r%36], r/36
+	buf[15], r = Base36Alphabet[r%36], r/36
+	buf[14], r = Base36Alphabet[r%36], r/36
+	buf[13] = Base36Alphabet[r]
+	num, r = num.quoRem64(b36MaxU64Divisor)
+	buf[12], r = Base36Alphabet[r%36], r/36
+	buf[11], r = Base36Alphabet[r%36], r/36
+	buf[10], r = Base36Alphabet[r%36], r/36
+	buf[9], r = Base36Alphabet[r%36], r/36
+	buf[8], r = Base36Alphabet[r%36], r/36
+	buf[7], r = Base36Alphabet[r%36], r/36
+	buf[6], r = Base36Alphabet[r%36], r/36
+	buf[5], r = Base36Alphabet[r%36], r/36
+	buf[4], r = Base36Alphabet[r%36], r/36
+	buf[3], r = Base36Alphabet[r%36], r/36
+	buf[2], r = Base36Alphabet[r%36], r/36
+	buf[1] = Base36Alphabet[r]
+	r = num.Lo
+	buf[0] = Base36Alphabet[r]
+}
+
+// Decode decodes a string according to Base36Alphabet into a uuid.UUID. Like
+// encoder.Decode, short inputs are padded with 0 (zero) and the same errors
+// are returned.
+func (e b36Encoder) Decode(s string) (u uuid.UUID, err error) {
+	var n uint128
+	var n64, ind uint64
+	var i int
+
+	for j, c := range s {
+		if c > 255 {
+			return u, &InvalidCharError{Char: c, Offset: j}
+		}
+		ind = uint64(reverseBase36[c])
+		if ind == 255 {
+			return u, &InvalidCharError{Char: c, Offset: j}
+		}
+		n64 = n64*36 + ind
+		i++
+		if i == b36MaxU64Digits {
+			n, err = n.mulAdd64(b36MaxU64Divisor, n64)
+			if err != nil {
+				return
+			}
+			i = 0
+			n64 = 0
+		}
+	}
+	if i > 0 {
+		n, err = n.mulAdd64(powsBase36[i], n64)
+		if err != nil {
+			return
+		}
+	}
+	binary.BigEndian.PutUint64(u[:8], n.Hi)
+	binary.BigEndian.PutUint64(u[8:], n.Lo)
+	return
+}
+
+// DecodeStrict is like Decode but requires s to be exactly 25 characters long,
+// so that every UUID has exactly one valid encoding. It returns ErrLength if s
+// has the wrong length and ErrOverflow if s represents a number that does not
+// fit in 128 bits.
+func (e b36Encoder) DecodeStrict(s string) (uuid.UUID, error) {
+	if len(s) != 25 {
+		return uuid.UUID{}, fmt.Errorf("%w: expected 25 characters, got %d", ErrLength, utf8.RuneCountInString(s))
+	}
+	return e.Decode(s)
+}
+
+// DecodeBytes is like Decode but takes a byte slice, which avoids converting
+// it to a string first.
+func (e b36Encoder) DecodeBytes(b []byte) (uuid.UUID, error) {
+	return e.Decode(unsafe.String(unsafe.SliceData(b), len(b)))
+}
+
+// powsBase36 holds the powers of 36 that fit in a uint64.
+var powsBase36 = [12]uint64{1, 36, 1296, 46656, 1679616, 60466176, 2176782336, 78364164096, 2821109907456, 101559956668416, 3656158440062976, 131621703842267136}
+
+// reverseBase36 is a lookup table for fast decoding, like reverseB57.
+var reverseBase36 = [256]byte{
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	0, 1, 2, 3, 4, 5, 6, 7,
+	8, 9, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 10, 11, 12, 13, 14, 15, 16,
+	17, 18, 19, 20, 21, 22, 23, 24,
+	25, 26, 27, 28, 29, 30, 31, 32,
+	33, 34, 35, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+}
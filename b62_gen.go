@@ -0,0 +1,183 @@
+// Code generated by genencoder -name Base62 -type b62Encoder; DO NOT EDIT.
+
+package shortuuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/google/uuid"
+)
+
+// Base62Alphabet is the alphabet used by Base62Encoder.
+const Base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+const (
+	b62MaxU64Digits  = 10
+	b62MaxU64Divisor = 839299365868340224 // 62^10
+)
+
+var b62Alphabet = newAlphabet(Base62Alphabet)
+
+// b62Encoder is an unrolled encoder for Base62Alphabet, generated like
+// b57Encoder. Encoded UUIDs are always 22 characters long.
+type b62Encoder struct{}
+
+func (e b62Encoder) alpha() *alphabet {
+	return &b62Alphabet
+}
+
+func (e b62Encoder) Encode(u uuid.UUID) string {
+	var buf [22]byte
+	e.encode(&buf, u)
+	return unsafe.String(unsafe.SliceData(buf[:]), 22)
+}
+
+// AppendEncode appends the encoded form of u to dst and returns the extended
+// buffer. It does not allocate if dst has enough capacity.
+func (e b62Encoder) AppendEncode(dst []byte, u uuid.UUID) []byte {
+	var buf [22]byte
+	e.encode(&buf, u)
+	return append(dst, buf[:]...)
+}
+
+// EncodeTo writes the encoded form of u to dst and returns the number of bytes
+// written, which is always 22. It returns io.ErrShortBuffer if dst is too
+// small, in which case nothing is written.
+func (e b62Encoder) EncodeTo(dst []byte, u uuid.UUID) (int, error) {
+	if len(dst) < 22 {
+		return 0, io.ErrShortBuffer
+	}
+	e.encode((*[22]byte)(dst), u)
+	return 22, nil
+}
+
+func (e b62Encoder) encode(buf *[22]byte, u uuid.UUID) {
+	num := uint128{
+		binary.BigEndian.Uint64(u[8:]),
+		binary.BigEndian.Uint64(u[:8]),
+	}
+	var r uint64
+	num, r = num.quoRem64(b62MaxU64Divisor)
+	buf[21], r = Base62Alphabet[r%62], r/62
+	buf[20], r = Base62Alphabet[r%62], r/62
+	buf[19], r = Base62Alphabet[r%62], r/62
+	buf[18], r = Base62Alphabet[r%62], r/62
+	buf[17], r = Base62Alphabet[r%62], r/62
+	buf[16], r = Base62Alphabet[r%62], r/62
+	buf[15], r = Base62Alphabet[r%62], r/62
+	buf[14], r = Base62Alphabet[r%62], r/62
+	buf[13], r = Base62Alphabet[r%62], r/62
+	buf[12] = Base62Alphabet[r]
+	num, r = num.quoRem64(b62MaxU64Divisor)
+	buf[11], r = Base62Alphabet[r%62], r/62
+	buf[10], r = Base62Alphabet[r%62], r/62
+	buf[9], r = Base62Alphabet[r%62], r/62
+	buf[8], r = Base62Alphabet[r%62], r/62
+	buf[7], r = Base62Alphabet[r%62], r/62
+	buf[6], r = Base62Alphabet[r%62], r/62
+	buf[5], r = Base62Alphabet[r%62], r/62
+	buf[4], r = Base62Alphabet[r%62], r/62
+	buf[3], r = Base62Alphabet[r%62], r/62
+	buf[2] = Base62Alphabet[r]
+	r = num.Lo
+	buf[1], r = Base62Alphabet[r%62], r/62
+	buf[0] = Base62Alphabet[r]
+}
+
+// Decode decodes a string according to Base62Alphabet into a uuid.UUID. Like
+// encoder.Decode, short inputs are padded with 0 (zero) and the same errors
+// are returned.
+func (e b62Encoder) Decode(s string) (u uuid.UUID, err error) {
+	var n uint128
+	var n64, ind uint64
+	var i int
+
+	for j, c := range s {
+		if c > 255 {
+			return u, &InvalidCharError{Char: c, Offset: j}
+		}
+		ind = uint64(reverseBase62[c])
+		if ind == 255 {
+			return u, &InvalidCharError{Char: c, Offset: j}
+		}
+		n64 = n64*62 + ind
+		i++
+		if i == b62MaxU64Digits {
+			n, err = n.mulAdd64(b62MaxU64Divisor, n64)
+			if err != nil {
+				return
+			}
+			i = 0
+			n64 = 0
+		}
+	}
+	if i > 0 {
+		n, err = n.mulAdd64(powsBase62[i], n64)
+		if err != nil {
+			return
+		}
+	}
+	binary.BigEndian.PutUint64(u[:8], n.Hi)
+	binary.BigEndian.PutUint64(u[8:], n.Lo)
+	return
+}
+
+// DecodeStrict is like Decode but requires s to be exactly 22 characters long,
+// so that every UUID has exactly one valid encoding. It returns ErrLength if s
+// has the wrong length and ErrOverflow if s represents a number that does not
+// fit in 128 bits.
+func (e b62Encoder) DecodeStrict(s string) (uuid.UUID, error) {
+	if len(s) != 22 {
+		return uuid.UUID{}, fmt.Errorf("%w: expected 22 characters, got %d", ErrLength, utf8.RuneCountInString(s))
+	}
+	return e.Decode(s)
+}
+
+// DecodeBytes is like Decode but takes a byte slice, which avoids converting
+// it to a string first.
+func (e b62Encoder) DecodeBytes(b []byte) (uuid.UUID, error) {
+	return e.Decode(unsafe.String(unsafe.SliceData(b), len(b)))
+}
+
+// powsBase62 holds the powers of 62 that fit in a uint64.
+var powsBase62 = [10]uint64{1, 62, 3844, 238328, 14776336, 916132832, 56800235584, 3521614606208, 218340105584896, 13537086546263552}
+
+// reverseBase62 is a lookup table for fast decoding, like reverseB57.
+var reverseBase62 = [256]byte{
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	0, 1, 2, 3, 4, 5, 6, 7,
+	8, 9, 255, 255, 255, 255, 255, 255,
+	255, 10, 11, 12, 13, 14, 15, 16,
+	17, 18, 19, 20, 21, 22, 23, 24,
+	25, 26, 27, 28, 29, 30, 31, 32,
+	33, 34, 35, 255, 255, 255, 255, 255,
+	255, 36, 37, 38, 39, 40, 41, 42,
+	43, 44, 45, 46, 47, 48, 49, 50,
+	51, 52, 53, 54, 55, 56, 57, 58,
+	59, 60, 61, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+}
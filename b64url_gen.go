@@ -0,0 +1,183 @@
+// Code generated by genencoder -name Base64URL -type b64URLEncoder; DO NOT EDIT.
+
+package shortuuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/google/uuid"
+)
+
+// Base64URLAlphabet is the alphabet used by Base64URLEncoder.
+const Base64URLAlphabet = "-0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz"
+
+const (
+	b64URLMaxU64Digits  = 10
+	b64URLMaxU64Divisor = 1152921504606846976 // 64^10
+)
+
+var b64URLAlphabet = newAlphabet(Base64URLAlphabet)
+
+// b64URLEncoder is an unrolled encoder for Base64URLAlphabet, generated like
+// b57Encoder. Encoded UUIDs are always 22 characters long.
+type b64URLEncoder struct{}
+
+func (e b64URLEncoder) alpha() *alphabet {
+	return &b64URLAlphabet
+}
+
+func (e b64URLEncoder) Encode(u uuid.UUID) string {
+	var buf [22]byte
+	e.encode(&buf, u)
+	return unsafe.String(unsafe.SliceData(buf[:]), 22)
+}
+
+// AppendEncode appends the encoded form of u to dst and returns the extended
+// buffer. It does not allocate if dst has enough capacity.
+func (e b64URLEncoder) AppendEncode(dst []byte, u uuid.UUID) []byte {
+	var buf [22]byte
+	e.encode(&buf, u)
+	return append(dst, buf[:]...)
+}
+
+// EncodeTo writes the encoded form of u to dst and returns the number of bytes
+// written, which is always 22. It returns io.ErrShortBuffer if dst is too
+// small, in which case nothing is written.
+func (e b64URLEncoder) EncodeTo(dst []byte, u uuid.UUID) (int, error) {
+	if len(dst) < 22 {
+		return 0, io.ErrShortBuffer
+	}
+	e.encode((*[22]byte)(dst), u)
+	return 22, nil
+}
+
+func (e b64URLEncoder) encode(buf *[22]byte, u uuid.UUID) {
+	num := uint128{
+		binary.BigEndian.Uint64(u[8:]),
+		binary.BigEndian.Uint64(u[:8]),
+	}
+	var r uint64
+	num, r = num.quoRem64(b64URLMaxU64Divisor)
+	buf[21], r = Base64URLAlphabet[r%64], r/64
+	buf[20], r = Base64URLAlphabet[r%64], r/64
+	buf[19], r = Base64URLAlphabet[r%64], r/64
+	buf[18], r = Base64URLAlphabet[r%64], r/64
+	buf[17], r = Base64URLAlphabet[r%64], r/64
+	buf[16], r = Base64URLAlphabet[r%64], r/64
+	buf[15], r = Base64URLAlphabet[r%64], r/64
+	buf[14], r = Base64URLAlphabet[r%64], r/64
+	buf[13], r = Base64URLAlphabet[r%64], r/64
+	buf[12] = Base64URLAlphabet[r]
+	num, r = num.quoRem64(b64URLMaxU64Divisor)
+	buf[11], r = Base64URLAlphabet[r%64], r/64
+	buf[10], r = Base64URLAlphabet[r%64], r/64
+	buf[9], r = Base64URLAlphabet[r%64], r/64
+	buf[8], r = Base64URLAlphabet[r%64], r/64
+	buf[7], r = Base64URLAlphabet[r%64], r/64
+	buf[6], r = Base64URLAlphabet[r%64], r/64
+	buf[5], r = Base64URLAlphabet[r%64], r/64
+	buf[4], r = Base64URLAlphabet[r%64], r/64
+	buf[3], r = Base64URLAlphabet[r%64], r/64
+	buf[2] = Base64URLAlphabet[r]
+	r = num.Lo
+	buf[1], r = Base64URLAlphabet[r%64], r/64
+	buf[0] = Base64URLAlphabet[r]
+}
+
+// Decode decodes a string according to Base64URLAlphabet into a uuid.UUID. Like
+// encoder.Decode, short inputs are padded with 0 (zero) and the same errors
+// are returned.
+func (e b64URLEncoder) Decode(s string) (u uuid.UUID, err error) {
+	var n uint128
+	var n64, ind uint64
+	var i int
+
+	for j, c := range s {
+		if c > 255 {
+			return u, &InvalidCharError{Char: c, Offset: j}
+		}
+		ind = uint64(reverseBase64URL[c])
+		if ind == 255 {
+			return u, &InvalidCharError{Char: c, Offset: j}
+		}
+		n64 = n64*64 + ind
+		i++
+		if i == b64URLMaxU64Digits {
+			n, err = n.mulAdd64(b64URLMaxU64Divisor, n64)
+			if err != nil {
+				return
+			}
+			i = 0
+			n64 = 0
+		}
+	}
+	if i > 0 {
+		n, err = n.mulAdd64(powsBase64URL[i], n64)
+		if err != nil {
+			return
+		}
+	}
+	binary.BigEndian.PutUint64(u[:8], n.Hi)
+	binary.BigEndian.PutUint64(u[8:], n.Lo)
+	return
+}
+
+// DecodeStrict is like Decode but requires s to be exactly 22 characters long,
+// so that every UUID has exactly one valid encoding. It returns ErrLength if s
+// has the wrong length and ErrOverflow if s represents a number that does not
+// fit in 128 bits.
+func (e b64URLEncoder) DecodeStrict(s string) (uuid.UUID, error) {
+	if len(s) != 22 {
+		return uuid.UUID{}, fmt.Errorf("%w: expected 22 characters, got %d", ErrLength, utf8.RuneCountInString(s))
+	}
+	return e.Decode(s)
+}
+
+// DecodeBytes is like Decode but takes a byte slice, which avoids converting
+// it to a string first.
+func (e b64URLEncoder) DecodeBytes(b []byte) (uuid.UUID, error) {
+	return e.Decode(unsafe.String(unsafe.SliceData(b), len(b)))
+}
+
+// powsBase64URL holds the powers of 64 that fit in a uint64.
+var powsBase64URL = [10]uint64{1, 64, 4096, 262144, 16777216, 1073741824, 68719476736, 4398046511104, 281474976710656, 18014398509481984}
+
+// reverseBase64URL is a lookup table for fast decoding, like reverseB57.
+var reverseBase64URL = [256]byte{
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 0, 255, 255,
+	1, 2, 3, 4, 5, 6, 7, 8,
+	9, 10, 255, 255, 255, 255, 255, 255,
+	255, 11, 12, 13, 14, 15, 16, 17,
+	18, 19, 20, 21, 22, 23, 24, 25,
+	26, 27, 28, 29, 30, 31, 32, 33,
+	34, 35, 36, 255, 255, 255, 255, 37,
+	255, 38, 39, 40, 41, 42, 43, 44,
+	45, 46, 47, 48, 49, 50, 51, 52,
+	53, 54, 55, 56, 57, 58, 59, 60,
+	61, 62, 63, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+}
@@ -0,0 +1,156 @@
+package shortuuid
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"sync"
+	"unsafe"
+
+	"github.com/google/uuid"
+)
+
+// batchParallelMin is the minimum number of IDs each goroutine encodes in
+// NewBatchParallel. Smaller batches are encoded on the calling goroutine.
+const batchParallelMin = 4096
+
+// NewBatch returns n new UUIDv4s, encoded with base57. It is like calling New
+// n times, but much faster for large n: the randomness for all IDs is read
+// from crypto/rand at once, and the IDs share a single backing allocation.
+// Unlike New, it doesn't use the source set with uuid.SetRand.
+//
+// Panics if n is negative or if the system's secure random number generator
+// fails.
+func NewBatch(n int) []string {
+	return defaultGenerator.NewBatch(n)
+}
+
+// AppendBatch is like NewBatch but appends the IDs to dst and returns the
+// extended slice.
+func AppendBatch(dst []string, n int) []string {
+	return defaultGenerator.AppendBatch(dst, n)
+}
+
+// NewBatchParallel is like NewBatch but spreads the encoding over
+// GOMAXPROCS goroutines when n is large.
+func NewBatchParallel(n int) []string {
+	return defaultGenerator.NewBatchParallel(n)
+}
+
+// NewBatch returns n new UUIDs, encoded with the Generator's encoder.
+//
+// The randomness for all IDs is read from the Generator's source in a single
+// read, and if the encoder implements AppendEncoder the IDs are encoded into
+// one shared byte slice. That slice stays in memory as long as any of the IDs
+// is referenced. If the Generator has a source, the result is the same as
+// calling New n times. If it has none, crypto/rand is read directly instead of
+// the source set with uuid.SetRand.
+//
+// Panics if n is negative or if the source of randomness fails.
+func (g *Generator) NewBatch(n int) []string {
+	checkBatchSize(n)
+	return g.AppendBatch(make([]string, 0, n), n)
+}
+
+// AppendBatch is like NewBatch but appends the IDs to dst and returns the
+// extended slice.
+func (g *Generator) AppendBatch(dst []string, n int) []string {
+	checkBatchSize(n)
+	dst = slices.Grow(dst, n)
+	g.encodeBatch(dst[len(dst):len(dst)+n], g.batchUUIDs(n))
+	return dst[:len(dst)+n]
+}
+
+// NewBatchParallel is like NewBatch but spreads the encoding over
+// GOMAXPROCS goroutines when n is large. The randomness is still read on the
+// calling goroutine, so the result is the same as NewBatch for the same
+// source, and UUIDv7s are still in order.
+func (g *Generator) NewBatchParallel(n int) []string {
+	checkBatchSize(n)
+	ids := make([]string, n)
+	raw := g.batchUUIDs(n)
+
+	workers := min(runtime.GOMAXPROCS(0), n/batchParallelMin)
+	if workers <= 1 {
+		g.encodeBatch(ids, raw)
+		return ids
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		lo, hi := n*w/workers, n*(w+1)/workers
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.encodeBatch(ids[lo:hi], raw[lo*16:hi*16])
+		}()
+	}
+	wg.Wait()
+	return ids
+}
+
+// checkBatchSize panics with a clear error if n is negative, instead of
+// leaving it to make.
+func checkBatchSize(n int) {
+	if n < 0 {
+		panic(fmt.Errorf("shortuuid: negative batch size %d", n))
+	}
+}
+
+// batchUUIDs returns n new UUIDs of the Generator's version, laid out back to
+// back in a single slice.
+func (g *Generator) batchUUIDs(n int) []byte {
+	buf := make([]byte, n*16)
+	if _, err := io.ReadFull(g.randReader(), buf); err != nil {
+		panic(err)
+	}
+
+	// Each UUID is built from the 16 bytes it replaces, which have already
+	// been read by the time it is written back.
+	r := bytes.NewReader(buf)
+	for i := 0; i < n; i++ {
+		var u uuid.UUID
+		var err error
+		if g.version == 7 {
+			u, err = uuid.NewV7FromReader(r)
+		} else {
+			u, err = uuid.NewRandomFromReader(r)
+		}
+		if err != nil {
+			panic(err)
+		}
+		copy(buf[i*16:], u[:])
+	}
+	return buf
+}
+
+// encodeBatch encodes the UUIDs in raw into ids.
+func (g *Generator) encodeBatch(ids []string, raw []byte) {
+	enc := g.encoder()
+	ae, ok := enc.(AppendEncoder)
+	if !ok {
+		for i := range ids {
+			ids[i] = enc.Encode(uuid.UUID(raw[i*16:]))
+		}
+		return
+	}
+
+	// The IDs point into slab. Bytes referenced by an ID are never written
+	// again, and if an append has to reallocate the earlier IDs keep the old
+	// array alive, so a variable-length encoder only costs extra allocations.
+	var slab []byte
+	for i := range ids {
+		start := len(slab)
+		slab = ae.AppendEncode(slab, uuid.UUID(raw[i*16:]))
+		if i == 0 {
+			// Size the slab from the length of the first ID.
+			s := make([]byte, len(slab), len(slab)*len(ids))
+			copy(s, slab)
+			slab = s
+		}
+		b := slab[start:]
+		ids[i] = unsafe.String(unsafe.SliceData(b), len(b))
+	}
+}
@@ -0,0 +1,116 @@
+package shortuuid
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewBatch(t *testing.T) {
+	ids := NewBatch(1000)
+	if len(ids) != 1000 {
+		t.Fatalf("expected 1000 IDs, got %d", len(ids))
+	}
+	seen := make(map[string]bool, len(ids))
+	for _, s := range ids {
+		if seen[s] {
+			t.Errorf("expected unique IDs, got %q twice", s)
+		}
+		seen[s] = true
+		u, err := DefaultEncoder.Decode(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Version() != 4 || u.Variant() != uuid.RFC4122 {
+			t.Errorf("expected a RFC 4122 UUIDv4, got %q", u)
+		}
+	}
+
+	if ids := NewBatch(0); len(ids) != 0 {
+		t.Errorf("expected no IDs, got %q", ids)
+	}
+}
+
+func TestNewBatchNegative(t *testing.T) {
+	for name, f := range map[string]func(){
+		"NewBatch":         func() { NewBatch(-1) },
+		"AppendBatch":      func() { AppendBatch(nil, -1) },
+		"NewBatchParallel": func() { NewBatchParallel(-1) },
+	} {
+		func() {
+			defer func() {
+				r := recover()
+				if err, ok := r.(error); !ok || err.Error() != "shortuuid: negative batch size -1" {
+					t.Errorf("expected %s to panic with %q, got %v", name, "shortuuid: negative batch size -1", r)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestAppendBatch(t *testing.T) {
+	ids := AppendBatch([]string{"a", "b"}, 3)
+	if len(ids) != 5 || ids[0] != "a" || ids[1] != "b" {
+		t.Fatalf("expected 2 existing and 3 new IDs, got %q", ids)
+	}
+	for _, s := range ids[2:] {
+		if _, err := DefaultEncoder.Decode(s); err != nil {
+			t.Errorf("expected a valid ID, got %q: %v", s, err)
+		}
+	}
+}
+
+func TestBatchMatchesNew(t *testing.T) {
+	hex, _ := NewEncoder("0123456789abcdef")
+	multibyte, _ := NewEncoder("23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghiうえおなにぬねのウエオナニヌネノ")
+	grouping, _ := NewGroupingEncoder(DefaultEncoder, 4, "-")
+	encoders := []Encoder{DefaultEncoder, Base62Encoder, hex, multibyte, grouping}
+
+	for _, enc := range encoders {
+		g1, _ := NewGenerator(NewSeededReader(1), enc, 4)
+		g2, _ := NewGenerator(NewSeededReader(1), enc, 4)
+		g3, _ := NewGenerator(NewSeededReader(1), enc, 4)
+		n := 3*batchParallelMin + 7
+		batch, parallel := g1.NewBatch(n), g2.NewBatchParallel(n)
+		for i := 0; i < n; i++ {
+			s := g3.New()
+			if batch[i] != s {
+				t.Fatalf("expected %q, got %q from NewBatch", s, batch[i])
+			}
+			if parallel[i] != s {
+				t.Fatalf("expected %q, got %q from NewBatchParallel", s, parallel[i])
+			}
+		}
+	}
+}
+
+func TestBatchV7(t *testing.T) {
+	g, _ := NewGenerator(nil, nil, 7)
+	ids := g.NewBatch(1000)
+	if !slices.IsSorted(ids) {
+		t.Error("expected UUIDv7 batch to be sorted")
+	}
+	u, err := DefaultEncoder.Decode(ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Version() != 7 {
+		t.Errorf("expected UUID version 7, got %d", u.Version())
+	}
+}
+
+func BenchmarkNewBatch(b *testing.B) {
+	ids := make([]string, 0, 1000)
+	for i := 0; i < b.N; i += len(ids) {
+		ids = AppendBatch(ids[:0], min(1000, b.N-i))
+	}
+}
+
+func BenchmarkNewBatchParallel(b *testing.B) {
+	const n = 1 << 16
+	for i := 0; i < b.N; i += n {
+		NewBatchParallel(min(n, b.N-i))
+	}
+}
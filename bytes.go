@@ -0,0 +1,212 @@
+package shortuuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/google/uuid"
+)
+
+// BytesEncoder encodes byte slices of any length, such as SHA-256 digests,
+// 64-bit Snowflake IDs or 96-bit xids, with an alphabet. The bytes are read as
+// a big-endian number, which is encoded most significant digit first and
+// padded to a length that only depends on the number of bytes, so leading zero
+// bytes are preserved and 16-byte inputs give the same output as NewEncoder.
+//
+// A BytesEncoder is immutable and safe for concurrent use.
+type BytesEncoder struct {
+	enc encoder
+}
+
+// NewBytesEncoder returns a BytesEncoder that uses the alphabet abc, such as
+// DefaultAlphabet. Like NewEncoder, the alphabet is sorted and deduplicated,
+// and must have at least 2 characters.
+func NewBytesEncoder(abc string) (*BytesEncoder, error) {
+	a, err := parseAlphabet(abc)
+	if err != nil {
+		return nil, err
+	}
+	return &BytesEncoder{encoder{a}}, nil
+}
+
+// EncodedLen returns the number of characters of the encoded form of n bytes.
+func (e *BytesEncoder) EncodedLen(n int) int {
+	return int(math.Ceil(float64(8*n) / math.Log2(float64(e.enc.alphabet.len))))
+}
+
+// EncodeBytes returns the encoded form of b, which is EncodedLen(len(b))
+// characters long.
+func (e *BytesEncoder) EncodeBytes(b []byte) string {
+	a := &e.enc.alphabet
+	n := e.EncodedLen(len(b))
+	buf := make([]byte, 0, n*int(a.maxBytes))
+
+	switch {
+	case len(b) == 16:
+		buf = e.enc.AppendEncode(buf, uuid.UUID(b))
+	case len(b) < 16:
+		var u uuid.UUID
+		copy(u[16-len(b):], b)
+		var d [128]int32
+		digits := e.enc.digits(&d, u)
+		for _, d := range digits[len(digits)-n:] {
+			buf = utf8.AppendRune(buf, a.chars[d])
+		}
+	default:
+		for _, d := range e.bigDigits(b, n) {
+			buf = utf8.AppendRune(buf, a.chars[d])
+		}
+	}
+	return unsafe.String(unsafe.SliceData(buf), len(buf))
+}
+
+// DecodeBytes returns the n bytes that s is the encoded form of.
+//
+// It returns ErrLength if s doesn't have exactly EncodedLen(n) characters, an
+// *InvalidCharError if s contains a character that is not part of the
+// alphabet, and ErrOverflow if s represents a number that does not fit in n
+// bytes.
+func (e *BytesEncoder) DecodeBytes(s string, n int) ([]byte, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("%w: negative byte count %d", ErrLength, n)
+	}
+	if l, c := e.EncodedLen(n), utf8.RuneCountInString(s); c != l {
+		return nil, fmt.Errorf("%w: expected %d characters, got %d", ErrLength, l, c)
+	}
+	if n > 16 {
+		return e.decodeBig(s, n)
+	}
+
+	u, err := e.enc.Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range u[:16-n] {
+		if c != 0 {
+			return nil, ErrOverflow
+		}
+	}
+	return u[16-n:], nil
+}
+
+// EncodeUint64 returns the encoded form of v, which is the same as the encoded
+// form of its 8 big-endian bytes.
+func (e *BytesEncoder) EncodeUint64(v uint64) string {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	return e.EncodeBytes(b[:])
+}
+
+// DecodeUint64 is the inverse of EncodeUint64. It returns the same errors as
+// DecodeBytes.
+func (e *BytesEncoder) DecodeUint64(s string) (uint64, error) {
+	b, err := e.DecodeBytes(s, 8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// toLimbs returns b as a big-endian slice of 64-bit limbs.
+func toLimbs(b []byte) []uint64 {
+	limbs := make([]uint64, (len(b)+7)/8)
+	buf := make([]byte, len(limbs)*8)
+	copy(buf[len(buf)-len(b):], b)
+	for i := range limbs {
+		limbs[i] = binary.BigEndian.Uint64(buf[i*8:])
+	}
+	return limbs
+}
+
+// bigDigits returns the n base-N digits of b, most significant first. It is
+// the multi-limb version of encoder.digits, and divides by alphabet.pow one
+// limb at a time like uint128.quoRem64.
+func (e *BytesEncoder) bigDigits(b []byte, n int) []int32 {
+	a := &e.enc.alphabet
+	l := uint64(a.len)
+	limbs := toLimbs(b)
+	digits := make([]int32, n)
+
+	i, start := n-1, 0
+	for {
+		for start < len(limbs) && limbs[start] == 0 {
+			start++
+		}
+		if start == len(limbs) {
+			return digits // the remaining digits are 0
+		}
+		var r uint64
+		for j := start; j < len(limbs); j++ {
+			limbs[j], r = bits.Div64(r, limbs[j], a.pow)
+		}
+		for k := 0; k < a.powN && i >= 0; k++ {
+			digits[i] = int32(r % l)
+			r /= l
+			i--
+		}
+	}
+}
+
+// decodeBig is the multi-limb version of encoder.Decode, for more than 16
+// bytes.
+func (e *BytesEncoder) decodeBig(s string, n int) ([]byte, error) {
+	a := &e.enc.alphabet
+	l := uint64(a.len)
+	limbs := make([]uint64, (n+7)/8)
+
+	// mulAdd sets limbs to limbs*m + x, like uint128.mulAdd64.
+	mulAdd := func(m, x uint64) error {
+		carry := x
+		for j := len(limbs) - 1; j >= 0; j-- {
+			hi, lo := bits.Mul64(limbs[j], m)
+			var c uint64
+			limbs[j], c = bits.Add64(lo, carry, 0)
+			carry = hi + c
+		}
+		if carry != 0 {
+			return ErrOverflow
+		}
+		return nil
+	}
+
+	var n64 uint64
+	var i int
+	for j, c := range s {
+		index, err := a.Index(c)
+		if err != nil {
+			return nil, &InvalidCharError{Char: c, Offset: j}
+		}
+		n64 = n64*l + uint64(index)
+		i++
+		if i == a.powN {
+			if err := mulAdd(a.pow, n64); err != nil {
+				return nil, err
+			}
+			i, n64 = 0, 0
+		}
+	}
+	if i > 0 {
+		m := l
+		for ; i > 1; i-- {
+			m *= l
+		}
+		if err := mulAdd(m, n64); err != nil {
+			return nil, err
+		}
+	}
+
+	buf := make([]byte, len(limbs)*8)
+	for j, limb := range limbs {
+		binary.BigEndian.PutUint64(buf[j*8:], limb)
+	}
+	for _, c := range buf[:len(buf)-n] {
+		if c != 0 {
+			return nil, ErrOverflow
+		}
+	}
+	return buf[len(buf)-n:], nil
+}
@@ -0,0 +1,130 @@
+package shortuuid
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestBytesEncoder(t *testing.T) {
+	enc, err := NewBytesEncoder(DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Computed with Python's arbitrary-precision integers.
+	tests := []struct {
+		hex, s string
+	}{
+		{"", ""},
+		{"00", "22"},
+		{"ff", "6V"},
+		{"000001", "22223"},
+		{"00000000000004d2", "222222222Pf"},
+		{"ffffffffffffffff", "txLqViLENDx"},
+		{"0000000000000000000000ff", "2222222222222226V"},
+		{"64d1355fd0524bd983f439b93fb1c01f", "KwSysDpxcBU9FNhGkn2dCf"},
+		{"0000abababababababababababababababababab", "222Cp7u48RVdXHrRtPcWZTUpD522"},
+		{"ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", "UX9RgNisFvzSctEcFfCjZf3RJHmJEDsgZgGX8ia8L3nQ"},
+	}
+	for _, test := range tests {
+		b, _ := hex.DecodeString(test.hex)
+		if s := enc.EncodeBytes(b); s != test.s {
+			t.Errorf("expected %q, got %q", test.s, s)
+		}
+		if n := enc.EncodedLen(len(b)); n != len(test.s) {
+			t.Errorf("expected EncodedLen(%d) = %d, got %d", len(b), len(test.s), n)
+		}
+		got, err := enc.DecodeBytes(test.s, len(b))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !bytes.Equal(got, b) {
+			t.Errorf("expected %x, got %x", b, got)
+		}
+	}
+}
+
+func TestBytesEncoderHex(t *testing.T) {
+	enc, _ := NewBytesEncoder("0123456789abcdef")
+	for n := 0; n < 70; n++ {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = byte(i * 37)
+		}
+		exp := hex.EncodeToString(b)
+		if s := enc.EncodeBytes(b); s != exp {
+			t.Errorf("expected %q, got %q", exp, s)
+		}
+		got, err := enc.DecodeBytes(exp, n)
+		if err != nil || !bytes.Equal(got, b) {
+			t.Errorf("expected %x, got %x, %v", b, got, err)
+		}
+	}
+}
+
+func TestBytesEncoderMultibyte(t *testing.T) {
+	enc, _ := NewBytesEncoder("うえおなにぬねのウエオナニヌネノ")
+	b, _ := hex.DecodeString("0102030405060708090a0b0c0d0e0f101112")
+	exp := "うえうおうなうにうぬうねうのうウうエうオうナうニうヌうネうノえうえええお"
+	if s := enc.EncodeBytes(b); s != exp {
+		t.Errorf("expected %q, got %q", exp, s)
+	}
+	got, err := enc.DecodeBytes(exp, len(b))
+	if err != nil || !bytes.Equal(got, b) {
+		t.Errorf("expected %x, got %x, %v", b, got, err)
+	}
+}
+
+func TestBytesEncoderUint64(t *testing.T) {
+	enc, _ := NewBytesEncoder(DefaultAlphabet)
+	for _, v := range []uint64{0, 1, 1234, 1 << 63, math.MaxUint64} {
+		s := enc.EncodeUint64(v)
+		if len(s) != 11 {
+			t.Errorf("expected 11 characters, got %q", s)
+		}
+		got, err := enc.DecodeUint64(s)
+		if err != nil || got != v {
+			t.Errorf("expected %d, got %d, %v", v, got, err)
+		}
+	}
+	if s := enc.EncodeUint64(1234); s != "222222222Pf" {
+		t.Errorf("expected %q, got %q", "222222222Pf", s)
+	}
+}
+
+func TestBytesEncoderDecodeErrors(t *testing.T) {
+	enc, _ := NewBytesEncoder(DefaultAlphabet)
+	tests := []struct {
+		s   string
+		n   int
+		err error
+	}{
+		{"22", -1, ErrLength},
+		{"222", 1, ErrLength},
+		{"2", 1, ErrLength},
+		{"zz", 1, ErrOverflow},
+		{"zzzzzzzzzzz", 8, ErrOverflow},
+		{"zzzzzzzzzzzzzzzzzzzzzz", 16, ErrOverflow},
+		{"zzzzzzzzzzzzzzzzzzzzzzzzz", 18, ErrOverflow},
+		{"zzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzz", 32, ErrOverflow},
+		{"2222222222222222222222222222222222222222222l", 32, ErrInvalidChar},
+		{"2l", 1, ErrInvalidChar},
+	}
+	for _, test := range tests {
+		if _, err := enc.DecodeBytes(test.s, test.n); !errors.Is(err, test.err) {
+			t.Errorf("expected %v for %q (%d bytes), got %v", test.err, test.s, test.n, err)
+		}
+	}
+}
+
+func BenchmarkEncodeBytes32(b *testing.B) {
+	enc, _ := NewBytesEncoder(DefaultAlphabet)
+	buf := bytes.Repeat([]byte{0xab}, 32)
+	for i := 0; i < b.N; i++ {
+		enc.EncodeBytes(buf)
+	}
+}
@@ -0,0 +1,179 @@
+package shortuuid
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// checkEncoder wraps an alphabet-based encoder and appends a check character
+// to its output.
+type checkEncoder struct {
+	enc      alphabetEncoder
+	alphabet *alphabet
+	group    checkGroup
+}
+
+// NewCheckEncoder returns an Encoder that appends a check character to the
+// output of enc, and verifies and removes it again when decoding. enc must be
+// built on an alphabet of at least 3 characters: DefaultEncoder,
+// Base62Encoder, Base58Encoder, Base36Encoder, Base64URLEncoder,
+// LegacyPythonEncoder, or an encoder returned by NewEncoder or
+// NewLegacyPythonEncoder.
+//
+// The check character is computed using a generalization of Verhoeff's
+// algorithm to alphabets of any size, and catches all single character
+// errors and all transpositions of adjacent characters. Decode returns
+// ErrChecksum if the check fails. The returned Encoder implements
+// StrictDecoder, which also requires the part before the check character to
+// be accepted by the DecodeStrict method of enc.
+func NewCheckEncoder(enc Encoder) (Encoder, error) {
+	ae, ok := enc.(alphabetEncoder)
+	if !ok {
+		return nil, fmt.Errorf("unsupported encoder %T (must be based on an alphabet)", enc)
+	}
+	g, err := newCheckGroup(ae.alpha().len)
+	if err != nil {
+		return nil, err
+	}
+	return checkEncoder{enc: ae, alphabet: ae.alpha(), group: g}, nil
+}
+
+func (e checkEncoder) Encode(u uuid.UUID) string {
+	s := e.enc.Encode(u)
+	c := e.group.inv(e.checksum(s, 1))
+	return s + string(e.alphabet.chars[c])
+}
+
+func (e checkEncoder) Decode(s string) (uuid.UUID, error) {
+	return e.decode(s, false)
+}
+
+// DecodeStrict implements StrictDecoder.
+func (e checkEncoder) DecodeStrict(s string) (uuid.UUID, error) {
+	return e.decode(s, true)
+}
+
+func (e checkEncoder) decode(s string, strict bool) (u uuid.UUID, err error) {
+	c, size := utf8.DecodeLastRuneInString(s)
+	if size == 0 {
+		return uuid.UUID{}, fmt.Errorf("%w: missing check character", ErrLength)
+	}
+	payload := s[:len(s)-size]
+	if strict {
+		u, err = decodeStrict(e.enc, payload)
+	} else {
+		u, err = e.enc.Decode(payload)
+	}
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	if _, err := e.alphabet.Index(c); err != nil {
+		return uuid.UUID{}, &InvalidCharError{Char: c, Offset: len(payload)}
+	}
+	if e.checksum(s, 0) != 0 {
+		return uuid.UUID{}, ErrChecksum
+	}
+	return u, nil
+}
+
+// checksum computes σ^k(a_k)···σ^1(a_1)·σ^0(a_0), where a_i is the index of
+// the i:th character of s counted from the right, starting at offset. The
+// result is the identity element (0) for a string that ends with a valid check
+// character. Every character of s must be part of the alphabet.
+func (e checkEncoder) checksum(s string, offset int) int64 {
+	n := utf8.RuneCountInString(s) + offset - 1
+	var c int64
+	for _, r := range s {
+		x, _ := e.alphabet.Index(r)
+		for j := 0; j < n; j++ {
+			x = e.group.sigma(x)
+		}
+		c = e.group.mul(x, c)
+		n--
+	}
+	return c
+}
+
+// checkGroup is a group of order n = 2^k·m (m odd) together with an
+// anti-symmetric mapping σ, that is a permutation such that x·σ(y) != y·σ(x)
+// for all x != y. Elements are represented by the integers 0 to n-1, where 0
+// is the identity element. Depending on k, the group is:
+//
+//   - k = 0: the cyclic group Z_n with σ(x) = -x.
+//   - k = 1: the dihedral group D_m, where x = f·m + j represents s^f·r^j,
+//     with σ(r^j) = s·r^j and σ(s·r^j) = r^(1-j) (Gumm, 1985).
+//   - k ≥ 2: the group (Z_2)^k × Z_m, where x = w·2^k + v, with σ(v, w) =
+//     (A·v, -w) and A multiplication by t modulo t^k + t + 1 over GF(2). Both
+//     A and A+I are invertible, which is what makes σ anti-symmetric.
+//
+// There is no anti-symmetric mapping for a group of order 2.
+type checkGroup struct {
+	n, m int64
+	k    int
+}
+
+func newCheckGroup(n int64) (checkGroup, error) {
+	if n < 3 {
+		return checkGroup{}, errors.New("check characters require an alphabet of at least three characters")
+	}
+	k := bits.TrailingZeros64(uint64(n))
+	return checkGroup{n: n, m: n >> k, k: k}, nil
+}
+
+func (g checkGroup) mul(x, y int64) int64 {
+	switch g.k {
+	case 0:
+		return (x + y) % g.n
+	case 1:
+		f1, j1 := x/g.m, x%g.m
+		f2, j2 := y/g.m, y%g.m
+		if f2 == 1 {
+			j1 = g.m - j1
+		}
+		return (f1^f2)*g.m + (j1+j2)%g.m
+	default:
+		mask := int64(1)<<g.k - 1
+		w := ((x >> g.k) + (y >> g.k)) % g.m
+		return w<<g.k | (x^y)&mask
+	}
+}
+
+func (g checkGroup) inv(x int64) int64 {
+	switch g.k {
+	case 0:
+		return (g.n - x) % g.n
+	case 1:
+		if x >= g.m {
+			return x // reflections are their own inverses
+		}
+		return (g.m - x) % g.m
+	default:
+		mask := int64(1)<<g.k - 1
+		w := (g.m - x>>g.k) % g.m
+		return w<<g.k | x&mask
+	}
+}
+
+func (g checkGroup) sigma(x int64) int64 {
+	switch g.k {
+	case 0:
+		return (g.n - x) % g.n
+	case 1:
+		if x < g.m {
+			return g.m + x
+		}
+		return (g.m + 1 - x%g.m) % g.m
+	default:
+		mask := int64(1)<<g.k - 1
+		v := (x & mask) << 1
+		if v > mask {
+			v ^= mask + 1 | 3
+		}
+		w := (g.m - x>>g.k) % g.m
+		return w<<g.k | v
+	}
+}
@@ -0,0 +1,163 @@
+package shortuuid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCheckGroup(t *testing.T) {
+	for n := int64(3); n <= 70; n++ {
+		g, err := newCheckGroup(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen := make(map[int64]bool)
+		for x := int64(0); x < n; x++ {
+			seen[g.sigma(x)] = true
+			if g.mul(x, g.inv(x)) != 0 || g.mul(g.inv(x), x) != 0 {
+				t.Errorf("n=%d: %d·%d is not the identity", n, x, g.inv(x))
+			}
+			for y := int64(0); y < n; y++ {
+				if x != y && g.mul(x, g.sigma(y)) == g.mul(y, g.sigma(x)) {
+					t.Errorf("n=%d: sigma is not anti-symmetric for %d, %d", n, x, y)
+				}
+				for z := int64(0); z < n; z++ {
+					if g.mul(g.mul(x, y), z) != g.mul(x, g.mul(y, z)) {
+						t.Fatalf("n=%d: mul is not associative", n)
+					}
+				}
+			}
+		}
+		if int64(len(seen)) != n {
+			t.Errorf("n=%d: sigma is not a permutation", n)
+		}
+	}
+}
+
+func TestNewCheckEncoderErrors(t *testing.T) {
+	if _, err := NewCheckEncoder(encoder{newAlphabet("01")}); err == nil {
+		t.Error("expected an error for a binary alphabet")
+	}
+	enc, _ := NewCheckEncoder(DefaultEncoder)
+	if _, err := NewCheckEncoder(enc); err == nil {
+		t.Error("expected an error for an encoder without an alphabet")
+	}
+}
+
+func TestCheckEncoder(t *testing.T) {
+	alphabets := []string{
+		DefaultAlphabet,
+		"0123456789abcdef",
+		"0123456789abcdefghijklmnopqrstuvwxyz",
+		"0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz",
+		"うえおなにぬねのウエオナニヌネノ",
+	}
+	for _, abc := range alphabets {
+		base, err := NewEncoder(abc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if abc == DefaultAlphabet {
+			base = DefaultEncoder
+		}
+		enc, err := NewCheckEncoder(base)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chars := []rune(abc)
+		for _, test := range testVector[:20] {
+			u := uuid.MustParse(test.uuid)
+			s := enc.Encode(u)
+			if prefix := base.Encode(u); string([]rune(s)[:len([]rune(s))-1]) != prefix {
+				t.Errorf("expected %q to start with %q", s, prefix)
+			}
+			u2, err := enc.Decode(s)
+			if err != nil {
+				t.Errorf("unexpected error decoding %q: %v", s, err)
+				continue
+			}
+			if u != u2 {
+				t.Errorf("expected %q, got %q", u, u2)
+			}
+
+			rs := []rune(s)
+			for i := range rs {
+				orig := rs[i]
+				for _, c := range chars {
+					if c == orig {
+						continue
+					}
+					rs[i] = c
+					if _, err := enc.Decode(string(rs)); err == nil {
+						t.Errorf("expected substitution in %q to be detected", string(rs))
+					}
+				}
+				rs[i] = orig
+			}
+			for i := 0; i < len(rs)-1; i++ {
+				if rs[i] == rs[i+1] {
+					continue
+				}
+				rs[i], rs[i+1] = rs[i+1], rs[i]
+				if _, err := enc.Decode(string(rs)); err == nil {
+					t.Errorf("expected transposition in %q to be detected", string(rs))
+				}
+				rs[i], rs[i+1] = rs[i+1], rs[i]
+			}
+		}
+	}
+}
+
+func TestCheckEncoderDecodeErrors(t *testing.T) {
+	enc, err := NewCheckEncoder(DefaultEncoder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := enc.Encode(uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f"))
+
+	if _, err := enc.Decode(""); !errors.Is(err, ErrLength) {
+		t.Errorf("expected %v, got %v", ErrLength, err)
+	}
+
+	// Leading zeros don't change the check character for base57, so a short
+	// payload passes the check, but not strict decoding.
+	one := enc.Encode(uuid.MustParse("00000000-0000-0000-0000-000000000001"))
+	short := one[len(one)-2:]
+	if _, err := enc.Decode(short); err != nil {
+		t.Errorf("unexpected error decoding %q: %v", short, err)
+	}
+	if _, err := enc.(StrictDecoder).DecodeStrict(short); !errors.Is(err, ErrLength) {
+		t.Errorf("expected %v for %q, got %v", ErrLength, short, err)
+	}
+	if _, err := enc.(StrictDecoder).DecodeStrict(s); err != nil {
+		t.Errorf("unexpected error decoding %q: %v", s, err)
+	}
+	var charErr *InvalidCharError
+	if _, err := enc.Decode(s[:22] + "0"); !errors.As(err, &charErr) || charErr.Offset != 22 {
+		t.Errorf("expected *InvalidCharError at offset 22, got %v", err)
+	}
+	if _, err := enc.Decode("0" + s[1:]); !errors.As(err, &charErr) || charErr.Offset != 0 {
+		t.Errorf("expected *InvalidCharError at offset 0, got %v", err)
+	}
+	if _, err := enc.Decode(s[:21] + s[22:]); !errors.Is(err, ErrChecksum) {
+		t.Errorf("expected %v, got %v", ErrChecksum, err)
+	}
+}
+
+func BenchmarkCheckEncoderEncode(b *testing.B) {
+	enc, _ := NewCheckEncoder(DefaultEncoder)
+	u := uuid.New()
+	for i := 0; i < b.N; i++ {
+		enc.Encode(u)
+	}
+}
+
+func BenchmarkCheckEncoderDecode(b *testing.B) {
+	enc, _ := NewCheckEncoder(DefaultEncoder)
+	s := enc.Encode(uuid.New())
+	for i := 0; i < b.N; i++ {
+		_, _ = enc.Decode(s)
+	}
+}
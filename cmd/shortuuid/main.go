@@ -0,0 +1,239 @@
+// Command shortuuid generates and converts short UUIDs.
+//
+// Usage:
+//
+//	shortuuid new [-n count] [-alphabet abc] [-v7] [-namespace name]
+//	shortuuid encode [-alphabet abc] [uuid ...]
+//	shortuuid decode [-alphabet abc] [shortuuid ...]
+//	shortuuid convert -from abc -to abc [shortuuid ...]
+//
+// The encode, decode and convert subcommands read one ID per line from
+// standard input if no arguments are given.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lithammer/shortuuid/v4"
+)
+
+const usage = `Usage:
+  shortuuid new [-n count] [-alphabet abc] [-v7] [-namespace name]
+  shortuuid encode [-alphabet abc] [uuid ...]
+  shortuuid decode [-alphabet abc] [shortuuid ...]
+  shortuuid convert -from abc -to abc [shortuuid ...]
+
+The encode, decode and convert subcommands read one ID per line from
+standard input if no arguments are given.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the process exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	var cmd func(fs *flag.FlagSet, args []string, r io.Reader, w io.Writer) error
+	switch args[0] {
+	case "new":
+		cmd = cmdNew
+	case "encode":
+		cmd = cmdEncode
+	case "decode":
+		cmd = cmdDecode
+	case "convert":
+		cmd = cmdConvert
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "shortuuid: unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("shortuuid "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	err := cmd(fs, args[1:], stdin, stdout)
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	case err != nil:
+		fmt.Fprintf(stderr, "shortuuid %s: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+// errUsage is returned when a subcommand's flags could not be parsed. The
+// flag package has already printed the details.
+var errUsage = errors.New("usage error")
+
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	return nil
+}
+
+// encoderFor returns DefaultEncoder if abc is empty and an encoder for the
+// alphabet abc otherwise.
+func encoderFor(abc string) (shortuuid.Encoder, error) {
+	if abc == "" {
+		return shortuuid.DefaultEncoder, nil
+	}
+	return shortuuid.NewEncoder(abc)
+}
+
+func cmdNew(fs *flag.FlagSet, args []string, _ io.Reader, w io.Writer) error {
+	n := fs.Int("n", 1, "number of IDs to generate")
+	abc := fs.String("alphabet", "", "alphabet to encode with (default base57)")
+	v7 := fs.Bool("v7", false, "generate time-ordered UUIDv7 IDs")
+	namespace := fs.String("namespace", "", "generate UUIDv5 IDs from `name`")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *v7 && *namespace != "" {
+		return errors.New("-v7 and -namespace are mutually exclusive")
+	}
+	enc, err := encoderFor(*abc)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < *n; i++ {
+		var s string
+		switch {
+		case *v7:
+			s = shortuuid.NewV7WithEncoder(enc)
+		case *namespace != "":
+			s = shortuuid.NewV5WithEncoder(enc, namespaceFor(*namespace), []byte(*namespace))
+		default:
+			s = shortuuid.NewWithEncoder(enc)
+		}
+		if _, err := fmt.Fprintln(w, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// namespaceFor returns the namespace that shortuuid.NewWithNamespace hashes
+// name in: the URL namespace for names starting with http:// or https://, and
+// the DNS namespace otherwise.
+func namespaceFor(name string) uuid.UUID {
+	lower := strings.ToLower(name)
+	if strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") {
+		return uuid.NameSpaceURL
+	}
+	return uuid.NameSpaceDNS
+}
+
+func cmdEncode(fs *flag.FlagSet, args []string, r io.Reader, w io.Writer) error {
+	abc := fs.String("alphabet", "", "alphabet to encode with (default base57)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	enc, err := encoderFor(*abc)
+	if err != nil {
+		return err
+	}
+	return eachLine(fs.Args(), r, w, func(s string) (string, error) {
+		u, err := uuid.Parse(s)
+		if err != nil {
+			return "", err
+		}
+		return enc.Encode(u), nil
+	})
+}
+
+func cmdDecode(fs *flag.FlagSet, args []string, r io.Reader, w io.Writer) error {
+	abc := fs.String("alphabet", "", "alphabet to decode with (default base57)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	enc, err := encoderFor(*abc)
+	if err != nil {
+		return err
+	}
+	return eachLine(fs.Args(), r, w, func(s string) (string, error) {
+		u, err := enc.Decode(s)
+		if err != nil {
+			return "", err
+		}
+		return u.String(), nil
+	})
+}
+
+func cmdConvert(fs *flag.FlagSet, args []string, r io.Reader, w io.Writer) error {
+	from := fs.String("from", "", "alphabet to decode with (default base57)")
+	to := fs.String("to", "", "alphabet to encode with (default base57)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	dec, err := encoderFor(*from)
+	if err != nil {
+		return err
+	}
+	enc, err := encoderFor(*to)
+	if err != nil {
+		return err
+	}
+	return eachLine(fs.Args(), r, w, func(s string) (string, error) {
+		u, err := dec.Decode(s)
+		if err != nil {
+			return "", err
+		}
+		return enc.Encode(u), nil
+	})
+}
+
+// eachLine applies fn to each of args, or to each line read from r if args
+// is empty, and writes the results to w one per line. Empty lines are skipped.
+func eachLine(args []string, r io.Reader, w io.Writer, fn func(string) (string, error)) error {
+	process := func(s string) error {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return nil
+		}
+		out, err := fn(s)
+		if err != nil {
+			return fmt.Errorf("%q: %w", s, err)
+		}
+		_, err = fmt.Fprintln(w, out)
+		return err
+	}
+
+	if len(args) > 0 {
+		for _, arg := range args {
+			if err := process(arg); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if err := process(sc.Text()); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
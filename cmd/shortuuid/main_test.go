@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lithammer/shortuuid/v4"
+)
+
+func runTest(t *testing.T, stdin string, args ...string) (string, string, int) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return stdout.String(), stderr.String(), code
+}
+
+func TestNew(t *testing.T) {
+	out, _, code := runTest(t, "", "new", "-n", "3")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	lines := strings.Fields(out)
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 IDs, got %d", len(lines))
+	}
+	for _, s := range lines {
+		if _, err := shortuuid.DefaultEncoder.Decode(s); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestNewNamespace(t *testing.T) {
+	out, _, code := runTest(t, "", "new", "-namespace", "http://www.example.com/")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if out != "nzUQAfy7CW4Dd4kzLguPSV\n" {
+		t.Errorf("expected %q, got %q", "nzUQAfy7CW4Dd4kzLguPSV\n", out)
+	}
+
+	out, _, _ = runTest(t, "", "new", "-namespace", "http://www.example.com/", "-alphabet", "0123456789abcdef")
+	if out != "fcde3c852270590f9e7cee003d65e0e2\n" {
+		t.Errorf("expected %q, got %q", "fcde3c852270590f9e7cee003d65e0e2\n", out)
+	}
+}
+
+func TestNewNamespaceMatchesLibrary(t *testing.T) {
+	for _, name := range []string{"example.com", "HTTPS://example.com/", "http:/example.com"} {
+		out, _, _ := runTest(t, "", "new", "-namespace", name)
+		if exp := shortuuid.NewWithNamespace(name) + "\n"; out != exp {
+			t.Errorf("expected %q for %q, got %q", exp, name, out)
+		}
+	}
+}
+
+func TestNewV7(t *testing.T) {
+	out, _, code := runTest(t, "", "new", "-v7", "-n", "2")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	lines := strings.Fields(out)
+	if len(lines) != 2 || lines[0] >= lines[1] {
+		t.Errorf("expected 2 ordered IDs, got %q", lines)
+	}
+
+	_, _, code = runTest(t, "", "new", "-v7", "-namespace", "example.com")
+	if code == 0 {
+		t.Error("expected -v7 and -namespace to be rejected")
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	out, _, code := runTest(t, "64d1355f-d052-4bd9-83f4-39b93fb1c01f\n\nf9ee01c3-2015-4716-930e-4d5449810833\n", "encode")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if exp := "KwSysDpxcBU9FNhGkn2dCf\nnUfojcH2M5j9j3Tk5A8mf7\n"; out != exp {
+		t.Errorf("expected %q, got %q", exp, out)
+	}
+
+	out, _, code = runTest(t, "", "decode", "KwSysDpxcBU9FNhGkn2dCf", "nUfojcH2M5j9j3Tk5A8mf7")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if exp := "64d1355f-d052-4bd9-83f4-39b93fb1c01f\nf9ee01c3-2015-4716-930e-4d5449810833\n"; out != exp {
+		t.Errorf("expected %q, got %q", exp, out)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	out, _, code := runTest(t, "KwSysDpxcBU9FNhGkn2dCf\n", "convert", "-to", "0123456789abcdef")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if exp := "64d1355fd0524bd983f439b93fb1c01f\n"; out != exp {
+		t.Errorf("expected %q, got %q", exp, out)
+	}
+
+	out, _, _ = runTest(t, "", "convert", "-from", "0123456789abcdef", "64d1355fd0524bd983f439b93fb1c01f")
+	if exp := "KwSysDpxcBU9FNhGkn2dCf\n"; out != exp {
+		t.Errorf("expected %q, got %q", exp, out)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{nil, 2},
+		{[]string{"bogus"}, 2},
+		{[]string{"new", "-bogus"}, 2},
+		{[]string{"new", "-alphabet", "a"}, 1},
+		{[]string{"encode", "not-a-uuid"}, 1},
+		{[]string{"decode", "0lIO"}, 1},
+	}
+	for _, test := range tests {
+		_, stderr, code := runTest(t, "", test.args...)
+		if code != test.code {
+			t.Errorf("%q: expected exit code %d, got %d", test.args, test.code, code)
+		}
+		if stderr == "" {
+			t.Errorf("%q: expected an error message", test.args)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package shortuuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/google/uuid"
+)
+
+// Base32CrockfordAlphabet is the alphabet used by Base32CrockfordEncoder. It
+// excludes I, L, O and U to avoid confusion and accidental obscenity.
+const Base32CrockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Base32CrockfordEncoder encodes UUIDs as 26 characters of Crockford's Base32,
+// which is case-insensitive and easy to dictate. Decoding is lenient as the
+// specification prescribes: lowercase letters are accepted, I and L are read
+// as 1, O is read as 0, and hyphens are ignored.
+var Base32CrockfordEncoder = crockfordEncoder{}
+
+// crockfordEncoder is an encoder for Crockford's Base32. Since 32 is a power
+// of 2, it works on the bits of the UUID directly.
+type crockfordEncoder struct{}
+
+func (e crockfordEncoder) Encode(u uuid.UUID) string {
+	var buf [26]byte
+	e.encode(&buf, u)
+	return unsafe.String(unsafe.SliceData(buf[:]), 26)
+}
+
+// AppendEncode appends the encoded form of u to dst and returns the extended
+// buffer. It does not allocate if dst has enough capacity.
+func (e crockfordEncoder) AppendEncode(dst []byte, u uuid.UUID) []byte {
+	var buf [26]byte
+	e.encode(&buf, u)
+	return append(dst, buf[:]...)
+}
+
+// EncodeTo writes the encoded form of u to dst and returns the number of bytes
+// written, which is always 26. It returns io.ErrShortBuffer if dst is too
+// small, in which case nothing is written.
+func (e crockfordEncoder) EncodeTo(dst []byte, u uuid.UUID) (int, error) {
+	if len(dst) < 26 {
+		return 0, io.ErrShortBuffer
+	}
+	e.encode((*[26]byte)(dst), u)
+	return 26, nil
+}
+
+func (e crockfordEncoder) encode(buf *[26]byte, u uuid.UUID) {
+	hi := binary.BigEndian.Uint64(u[:8])
+	lo := binary.BigEndian.Uint64(u[8:])
+	for i := 25; i >= 0; i-- {
+		buf[i] = Base32CrockfordAlphabet[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+}
+
+// Decode decodes a Crockford Base32 string into a uuid.UUID. Like
+// encoder.Decode, short inputs are padded with 0 (zero) and the same errors
+// are returned.
+func (e crockfordEncoder) Decode(s string) (uuid.UUID, error) {
+	u, _, err := e.decode(s)
+	return u, err
+}
+
+// DecodeStrict is like Decode but requires s to contain exactly 26
+// characters, not counting hyphens. It returns ErrLength if s has the wrong
+// length and ErrOverflow if s represents a number that does not fit in 128
+// bits.
+func (e crockfordEncoder) DecodeStrict(s string) (uuid.UUID, error) {
+	u, n, err := e.decode(s)
+	if err == nil && n != 26 {
+		err = fmt.Errorf("%w: expected 26 characters, got %d", ErrLength, n)
+	}
+	return u, err
+}
+
+// DecodeBytes is like Decode but takes a byte slice, which avoids converting
+// it to a string first.
+func (e crockfordEncoder) DecodeBytes(b []byte) (uuid.UUID, error) {
+	return e.Decode(unsafe.String(unsafe.SliceData(b), len(b)))
+}
+
+// decode decodes s and returns the number of characters it consisted of, not
+// counting hyphens.
+func (e crockfordEncoder) decode(s string) (u uuid.UUID, n int, err error) {
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '-' {
+			continue
+		}
+		v := reverseCrockford[c]
+		if v == 255 {
+			r, _ := utf8.DecodeRuneInString(s[i:])
+			return u, n, &InvalidCharError{Char: r, Offset: i}
+		}
+		if hi>>59 != 0 {
+			return u, n, ErrOverflow
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+		n++
+	}
+	binary.BigEndian.PutUint64(u[:8], hi)
+	binary.BigEndian.PutUint64(u[8:], lo)
+	return u, n, nil
+}
+
+// reverseCrockford is a lookup table for Crockford Base32 decoding, like
+// reverseB57. Lowercase letters map to the same values as uppercase, I and L
+// map to 1 and O maps to 0.
+var reverseCrockford = [256]byte{
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	0, 1, 2, 3, 4, 5, 6, 7,
+	8, 9, 255, 255, 255, 255, 255, 255,
+	255, 10, 11, 12, 13, 14, 15, 16,
+	17, 1, 18, 19, 1, 20, 21, 0,
+	22, 23, 24, 25, 26, 255, 27, 28,
+	29, 30, 31, 255, 255, 255, 255, 255,
+	255, 10, 11, 12, 13, 14, 15, 16,
+	17, 1, 18, 19, 1, 20, 21, 0,
+	22, 23, 24, 25, 26, 255, 27, 28,
+	29, 30, 31, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255,
+}
@@ -0,0 +1,104 @@
+package shortuuid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+var crockfordTestVector = []struct {
+	uuid      string
+	crockford string
+}{
+	{"00000000-0000-0000-0000-000000000000", "00000000000000000000000000"},
+	{"00000000-0000-0000-8000-000000000000", "00000000000008000000000000"},
+	{"0170eb09-dd9d-4682-a58f-b49eb481a40d", "01E3NGKQCX8T1AB3XMKTT8390D"},
+	{"64d1355f-d052-4bd9-83f4-39b93fb1c01f", "34T4TNZM2J9FCR7X1SQ4ZV3G0Z"},
+	{"f9ee01c3-2015-4716-930e-4d5449810833", "7SXR0W680N8WB963JDAH4R221K"},
+	{"ffffffff-ffff-ffff-ffff-ffffffffffff", "7ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+}
+
+func TestCrockfordEncoding(t *testing.T) {
+	for _, test := range crockfordTestVector {
+		u := uuid.MustParse(test.uuid)
+		s := Base32CrockfordEncoder.Encode(u)
+		if s != test.crockford {
+			t.Errorf("expected %q, got %q", test.crockford, s)
+		}
+		u2, err := Base32CrockfordEncoder.DecodeStrict(s)
+		if err != nil {
+			t.Error(err)
+		}
+		if u != u2 {
+			t.Errorf("expected %q, got %q", u, u2)
+		}
+	}
+}
+
+func TestCrockfordDecodingAliases(t *testing.T) {
+	u := uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f")
+	tests := []string{
+		"34t4tnzm2j9fcr7x1sq4zv3g0z",
+		"34T4TNZM2J9FCR7XISQ4ZV3GOZ",
+		"34T4TNZM2J9FCR7XLSQ4ZV3GoZ",
+		"34T4-TNZM-2J9F-CR7X-1SQ4-ZV3G-0Z",
+		"-34T4TNZM2J9FCR7X1SQ4ZV3G0Z-",
+	}
+	for _, s := range tests {
+		u2, err := Base32CrockfordEncoder.DecodeStrict(s)
+		if err != nil {
+			t.Errorf("unexpected error decoding %q: %v", s, err)
+			continue
+		}
+		if u != u2 {
+			t.Errorf("expected %q, got %q", u, u2)
+		}
+	}
+}
+
+func TestCrockfordDecodingErrors(t *testing.T) {
+	tests := []struct {
+		s   string
+		err error
+	}{
+		{"34T4TNZM2J9FCR7X1SQ4ZV3G0U", ErrInvalidChar},
+		{"34T4TNZM2J9FCR7X1SQ4ZV3G0ö", ErrInvalidChar},
+		{"80000000000000000000000000", ErrOverflow},
+	}
+	for _, test := range tests {
+		_, err := Base32CrockfordEncoder.Decode(test.s)
+		if !errors.Is(err, test.err) {
+			t.Errorf("expected %v for %q, got %v", test.err, test.s, err)
+		}
+	}
+
+	var charErr *InvalidCharError
+	_, err := Base32CrockfordEncoder.Decode("34T4-TNZM-2J9F-CR7X-1SQ4-ZV3G-ö")
+	if !errors.As(err, &charErr) || charErr.Char != 'ö' || charErr.Offset != 30 {
+		t.Errorf("expected 'ö' at offset 30, got %v", err)
+	}
+
+	u, err := Base32CrockfordEncoder.Decode("1")
+	if err != nil || u != uuid.MustParse("00000000-0000-0000-0000-000000000001") {
+		t.Errorf("expected lenient decoding of a short input, got %q, %v", u, err)
+	}
+	for _, s := range []string{"1", "000000000000000000000000000"} {
+		if _, err := Base32CrockfordEncoder.DecodeStrict(s); !errors.Is(err, ErrLength) {
+			t.Errorf("expected %v for %q, got %v", ErrLength, s, err)
+		}
+	}
+}
+
+func BenchmarkCrockfordEncoding(b *testing.B) {
+	u := uuid.New()
+	for i := 0; i < b.N; i++ {
+		Base32CrockfordEncoder.Encode(u)
+	}
+}
+
+func BenchmarkCrockfordDecoding(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = Base32CrockfordEncoder.Decode("7SXR0W680N8WB963JDAH4R221K")
+	}
+}
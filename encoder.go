@@ -3,8 +3,10 @@ package shortuuid
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
 	"math"
 	"math/bits"
+	"slices"
 	"unicode/utf8"
 	"unsafe"
 
@@ -18,6 +20,20 @@ type encoder struct {
 	alphabet alphabet
 }
 
+// alphabetEncoder is implemented by the encoders that are built on an
+// alphabet: DefaultEncoder, the generated Base62Encoder, Base58Encoder,
+// Base36Encoder and Base64URLEncoder, LegacyPythonEncoder, and the encoders
+// returned by NewEncoder and NewLegacyPythonEncoder. Base32CrockfordEncoder
+// doesn't implement it, since it decodes aliases of its characters.
+type alphabetEncoder interface {
+	Encoder
+	alpha() *alphabet
+}
+
+func (e encoder) alpha() *alphabet {
+	return &e.alphabet
+}
+
 // maxPow calculates the maximum power of b that fits in a uint64, returning
 // both the value (d = b^n) and the exponent n. This is used during encoding
 // to process the 128-bit UUID value in chunks that fit in 64-bit arithmetic.
@@ -33,51 +49,180 @@ func maxPow(b uint64) (d uint64, n int) {
 // Encode encodes uuid.UUID into a string using the most significant bits (MSB)
 // first according to the alphabet.
 func (e encoder) Encode(u uuid.UUID) string {
+	buf := e.AppendEncode(make([]byte, 0, int(e.alphabet.encLen)*int(e.alphabet.maxBytes)), u)
+	return unsafe.String(unsafe.SliceData(buf), len(buf)) // same as in strings.Builder
+}
+
+// AppendEncode appends the encoded form of u to dst and returns the extended
+// buffer. It does not allocate if dst has enough capacity.
+func (e encoder) AppendEncode(dst []byte, u uuid.UUID) []byte {
+	if e.alphabet.maxBytes == 1 {
+		n := len(dst)
+		dst = slices.Grow(dst, int(e.alphabet.encLen))[:n+int(e.alphabet.encLen)]
+		e.encodeSingleByte(dst[n:], u)
+		return dst
+	}
+	var digits [128]int32
+	for _, d := range e.digits(&digits, u) {
+		dst = utf8.AppendRune(dst, e.alphabet.chars[d])
+	}
+	return dst
+}
+
+// EncodeTo writes the encoded form of u to dst and returns the number of bytes
+// written. It returns io.ErrShortBuffer if dst is too small to hold the
+// encoded form, in which case nothing is written.
+func (e encoder) EncodeTo(dst []byte, u uuid.UUID) (int, error) {
+	if e.alphabet.maxBytes == 1 {
+		n := int(e.alphabet.encLen)
+		if len(dst) < n {
+			return 0, io.ErrShortBuffer
+		}
+		e.encodeSingleByte(dst[:n], u)
+		return n, nil
+	}
+	var digits [128]int32
+	ds := e.digits(&digits, u)
+	n := 0
+	for _, d := range ds {
+		n += utf8.RuneLen(e.alphabet.chars[d])
+	}
+	if len(dst) < n {
+		return 0, io.ErrShortBuffer
+	}
+	dst = dst[:0]
+	for _, d := range ds {
+		dst = utf8.AppendRune(dst, e.alphabet.chars[d])
+	}
+	return n, nil
+}
+
+// encodeSingleByte encodes u into buf, which must be exactly alphabet.encLen
+// bytes long. It may only be used if every character in the alphabet is a
+// single byte.
+func (e encoder) encodeSingleByte(buf []byte, u uuid.UUID) {
 	num := uint128{
 		binary.BigEndian.Uint64(u[8:]),
 		binary.BigEndian.Uint64(u[:8]),
 	}
-	var r, ind uint64
-	i := int(e.alphabet.encLen - 1)
-	buf := make([]byte, int64(e.alphabet.encLen)*int64(e.alphabet.maxBytes))
-	lastPlaced := len(buf)
+	var r uint64
+	i := len(buf) - 1
 	l := uint64(e.alphabet.len)
-	d, n := maxPow(l)
+	d, n := e.alphabet.pow, e.alphabet.powN
 
 	for num.Hi > 0 || num.Lo > 0 {
 		num, r = num.quoRem64(d)
 		for j := 0; j < n && i >= 0; j++ {
-			r, ind = r/l, r%l
-			c := e.alphabet.chars[ind]
-			if e.alphabet.maxBytes == 1 {
-				buf[i] = byte(c)
-				lastPlaced--
-			} else {
-				lastPlaced -= utf8.EncodeRune(buf[lastPlaced-utf8.RuneLen(c):], c)
-			}
+			buf[i] = byte(e.alphabet.chars[r%l])
+			r /= l
 			i--
 		}
 	}
-	firstRuneLen := utf8.RuneLen(e.alphabet.chars[0])
 	for ; i >= 0; i-- {
-		lastPlaced -= utf8.EncodeRune(buf[lastPlaced-firstRuneLen:], e.alphabet.chars[0])
+		buf[i] = byte(e.alphabet.chars[0])
 	}
-	buf = buf[lastPlaced:]
-	return unsafe.String(unsafe.SliceData(buf), len(buf)) // same as in strings.Builder
+}
+
+// digits stores the base-N digits of u in d, most significant first and
+// padded to alphabet.encLen digits, and returns them as a slice of d. Since
+// an alphabet has at least 2 characters, encLen never exceeds 128.
+func (e encoder) digits(d *[128]int32, u uuid.UUID) []int32 {
+	num := uint128{
+		binary.BigEndian.Uint64(u[8:]),
+		binary.BigEndian.Uint64(u[:8]),
+	}
+	var r uint64
+	n := int(e.alphabet.encLen)
+	i := n - 1
+	l := uint64(e.alphabet.len)
+	div, m := e.alphabet.pow, e.alphabet.powN
+
+	for num.Hi > 0 || num.Lo > 0 {
+		num, r = num.quoRem64(div)
+		for j := 0; j < m && i >= 0; j++ {
+			d[i] = int32(r % l)
+			r /= l
+			i--
+		}
+	}
+	for ; i >= 0; i-- {
+		d[i] = 0
+	}
+	return d[:n]
+}
+
+// DecodeStrict is like Decode but requires s to be exactly alphabet.encLen
+// characters long, so that every UUID has exactly one valid encoding. It
+// returns ErrLength if s has the wrong length and ErrOverflow if s represents
+// a number that does not fit in 128 bits.
+func (e encoder) DecodeStrict(s string) (uuid.UUID, error) {
+	if n := utf8.RuneCountInString(s); n != int(e.alphabet.encLen) {
+		return uuid.UUID{}, fmt.Errorf("%w: expected %d characters, got %d", ErrLength, e.alphabet.encLen, n)
+	}
+	return e.Decode(s)
+}
+
+// DecodeBytes is like Decode but takes a byte slice, which avoids converting
+// it to a string first.
+func (e encoder) DecodeBytes(b []byte) (uuid.UUID, error) {
+	return e.Decode(unsafe.String(unsafe.SliceData(b), len(b)))
 }
 
 // Decode decodes a string according to the alphabet into a uuid.UUID. If s is
 // too short, its most significant bits (MSB) will be padded with 0 (zero).
+// Use DecodeStrict to reject such inputs.
+//
+// It returns an *InvalidCharError if s contains a character that is not part
+// of the alphabet, and ErrOverflow if s represents a number that does not fit
+// in 128 bits.
 func (e encoder) Decode(s string) (u uuid.UUID, err error) {
 	var n uint128
-	var index int64
+	var n64, ind uint64
+	var i int
+	l := uint64(e.alphabet.len)
 
-	for _, char := range s {
-		index, err = e.alphabet.Index(char)
-		if err != nil {
-			return
+	// Like b57Encoder.Decode, digits are accumulated in n64 until it holds
+	// as many as fit in 64 bits, which saves most of the 128-bit arithmetic.
+	if ascii := e.alphabet.ascii; ascii != nil {
+		for j := 0; j < len(s); j++ {
+			ind = uint64(ascii[s[j]])
+			if ind == 255 {
+				c, _ := utf8.DecodeRuneInString(s[j:])
+				return u, &InvalidCharError{Char: c, Offset: j}
+			}
+			n64 = n64*l + ind
+			i++
+			if i == e.alphabet.powN {
+				n, err = n.mulAdd64(e.alphabet.pow, n64)
+				if err != nil {
+					return
+				}
+				i, n64 = 0, 0
+			}
 		}
-		n, err = n.mulAdd64(uint64(e.alphabet.len), uint64(index))
+	} else {
+		for j, c := range s {
+			index, ierr := e.alphabet.Index(c)
+			if ierr != nil {
+				return u, &InvalidCharError{Char: c, Offset: j}
+			}
+			n64 = n64*l + uint64(index)
+			i++
+			if i == e.alphabet.powN {
+				n, err = n.mulAdd64(e.alphabet.pow, n64)
+				if err != nil {
+					return
+				}
+				i, n64 = 0, 0
+			}
+		}
+	}
+	if i > 0 {
+		m := l
+		for ; i > 1; i-- {
+			m *= l
+		}
+		n, err = n.mulAdd64(m, n64)
 		if err != nil {
 			return
 		}
@@ -97,13 +242,41 @@ const (
 // for the common case of base57 encoding/decoding.
 type b57Encoder struct{}
 
+func (e b57Encoder) alpha() *alphabet {
+	return &defaultAlphabet
+}
+
 func (e b57Encoder) Encode(u uuid.UUID) string {
+	var buf [22]byte
+	e.encode(&buf, u)
+	return unsafe.String(unsafe.SliceData(buf[:]), 22)
+}
+
+// AppendEncode appends the encoded form of u to dst and returns the extended
+// buffer. It does not allocate if dst has enough capacity.
+func (e b57Encoder) AppendEncode(dst []byte, u uuid.UUID) []byte {
+	var buf [22]byte
+	e.encode(&buf, u)
+	return append(dst, buf[:]...)
+}
+
+// EncodeTo writes the encoded form of u to dst and returns the number of bytes
+// written, which is always 22. It returns io.ErrShortBuffer if dst is too
+// small, in which case nothing is written.
+func (e b57Encoder) EncodeTo(dst []byte, u uuid.UUID) (int, error) {
+	if len(dst) < 22 {
+		return 0, io.ErrShortBuffer
+	}
+	e.encode((*[22]byte)(dst), u)
+	return 22, nil
+}
+
+func (e b57Encoder) encode(buf *[22]byte, u uuid.UUID) {
 	num := uint128{
 		binary.BigEndian.Uint64(u[8:]),
 		binary.BigEndian.Uint64(u[:8]),
 	}
 	var r uint64
-	var buf [22]byte
 	num, r = num.quoRem64(b57MaxU64Divisor)
 	buf[21], r = DefaultAlphabet[r%57], r/57
 	buf[20], r = DefaultAlphabet[r%57], r/57
@@ -128,20 +301,26 @@ func (e b57Encoder) Encode(u uuid.UUID) string {
 	buf[2] = DefaultAlphabet[r/57]
 	buf[1] = DefaultAlphabet[num.Lo%57]
 	buf[0] = DefaultAlphabet[num.Lo/57]
-	return unsafe.String(unsafe.SliceData(buf[:]), 22)
 }
 
+// Decode decodes a base57 string into a uuid.UUID. Like encoder.Decode, if s
+// is too short, its most significant bits (MSB) will be padded with 0 (zero).
+// Use DecodeStrict to reject such inputs.
+//
+// It returns an *InvalidCharError if s contains a character that is not part
+// of the alphabet, and ErrOverflow if s represents a number that does not fit
+// in 128 bits.
 func (e b57Encoder) Decode(s string) (u uuid.UUID, err error) {
 	var n uint128
 	var n64, ind, i uint64
 
-	for _, c := range s {
+	for j, c := range s {
 		if c > 255 {
-			return u, fmt.Errorf("element '%v' is not part of the alphabet", c)
+			return u, &InvalidCharError{Char: c, Offset: j}
 		}
 		ind = uint64(reverseB57[c])
 		if ind == 255 {
-			return u, fmt.Errorf("element '%v' is not part of the alphabet", c)
+			return u, &InvalidCharError{Char: c, Offset: j}
 		}
 		n64 = n64*57 + ind
 		i++
@@ -154,15 +333,34 @@ func (e b57Encoder) Decode(s string) (u uuid.UUID, err error) {
 			n64 = 0
 		}
 	}
-	n, err = n.mulAdd64(57*57, n64)
-	if err != nil {
-		return
+	if i > 0 {
+		n, err = n.mulAdd64(pows57[i], n64)
+		if err != nil {
+			return
+		}
 	}
 	binary.BigEndian.PutUint64(u[:8], n.Hi)
 	binary.BigEndian.PutUint64(u[8:], n.Lo)
 	return
 }
 
+// DecodeStrict is like Decode but requires s to be exactly 22 characters long,
+// so that every UUID has exactly one valid encoding. It returns ErrLength if s
+// has the wrong length and ErrOverflow if s represents a number that does not
+// fit in 128 bits.
+func (e b57Encoder) DecodeStrict(s string) (uuid.UUID, error) {
+	if len(s) != 22 {
+		return uuid.UUID{}, fmt.Errorf("%w: expected 22 characters, got %d", ErrLength, utf8.RuneCountInString(s))
+	}
+	return e.Decode(s)
+}
+
+// DecodeBytes is like Decode but takes a byte slice, which avoids converting
+// it to a string first.
+func (e b57Encoder) DecodeBytes(b []byte) (uuid.UUID, error) {
+	return e.Decode(unsafe.String(unsafe.SliceData(b), len(b)))
+}
+
 // uint128 represents a 128-bit unsigned integer as two 64-bit words.
 // Lo contains the least significant 64 bits, and Hi contains the most
 // significant 64 bits.
@@ -188,11 +386,15 @@ func (u uint128) mulAdd64(m uint64, a uint64) (uint128, error) {
 	lo, c0 := bits.Add64(lo, a, 0)
 	hi, c1 := bits.Add64(hi, p1, c0)
 	if p0 != 0 || c1 != 0 {
-		return uint128{}, fmt.Errorf("number is out of range (need a 128-bit value)")
+		return uint128{}, ErrOverflow
 	}
 	return uint128{lo, hi}, nil
 }
 
+// pows57 holds the powers of 57 that fit in a uint64. Decode multiplies by
+// pows57[i] to make room for a last chunk of i digits.
+var pows57 = [b57MaxU64Digits]uint64{1, 57, 3249, 185193, 10556001, 601692057, 34296447249, 1954897493193, 111429157112001, 6351461955384057}
+
 // reverseB57 is a lookup table for fast base57 decoding. It maps ASCII byte
 // values (0-255) to their corresponding index in the default alphabet.
 // A value of 255 indicates that the byte is not part of the alphabet.
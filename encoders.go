@@ -0,0 +1,29 @@
+package shortuuid
+
+//go:generate go run ./internal/genencoder -name Base62 -type b62Encoder -alphabet 0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz -o b62_gen.go
+//go:generate go run ./internal/genencoder -name Base58 -type b58Encoder -alphabet 123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz -o b58_gen.go
+//go:generate go run ./internal/genencoder -name Base36 -type b36Encoder -alphabet 0123456789abcdefghijklmnopqrstuvwxyz -o b36_gen.go
+//go:generate go run ./internal/genencoder -name Base64URL -type b64URLEncoder -alphabet ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_ -o b64url_gen.go
+
+// The encoders below are generated by internal/genencoder, and produce the same
+// output as the encoders returned by NewEncoder for the same alphabets, only
+// faster.
+var (
+	// Base62Encoder encodes UUIDs as 22 characters of digits, uppercase and
+	// lowercase letters.
+	Base62Encoder = b62Encoder{}
+
+	// Base58Encoder encodes UUIDs as 22 characters of the alphabet used by
+	// Bitcoin, which excludes 0, I, O and l.
+	Base58Encoder = b58Encoder{}
+
+	// Base36Encoder encodes UUIDs as 25 characters of digits and lowercase
+	// letters.
+	Base36Encoder = b36Encoder{}
+
+	// Base64URLEncoder encodes UUIDs as 22 characters of the URL-safe base64
+	// alphabet. Like any alphabet in this package, it is sorted, so the
+	// output is a number in base 64 and not the same as
+	// base64.RawURLEncoding of the UUID bytes.
+	Base64URLEncoder = b64URLEncoder{}
+)
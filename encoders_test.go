@@ -0,0 +1,145 @@
+package shortuuid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+var generatedEncoders = []struct {
+	name     string
+	enc      AppendEncoder
+	alphabet string
+	encLen   int
+}{
+	{"Base62", Base62Encoder, Base62Alphabet, 22},
+	{"Base58", Base58Encoder, Base58Alphabet, 22},
+	{"Base36", Base36Encoder, Base36Alphabet, 25},
+	{"Base64URL", Base64URLEncoder, Base64URLAlphabet, 22},
+}
+
+func TestGeneratedEncoders(t *testing.T) {
+	max := uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff")
+	for _, test := range generatedEncoders {
+		generic := encoder{newAlphabet(test.alphabet)}
+		if int(generic.alphabet.encLen) != test.encLen {
+			t.Errorf("%s: expected length %d, got %d", test.name, generic.alphabet.encLen, test.encLen)
+		}
+		uuids := []uuid.UUID{uuid.Nil, max}
+		for _, v := range testVector {
+			uuids = append(uuids, uuid.MustParse(v.uuid))
+		}
+		for _, u := range uuids {
+			s := test.enc.Encode(u)
+			if exp := generic.Encode(u); s != exp {
+				t.Errorf("%s: expected %q, got %q", test.name, exp, s)
+			}
+			u2, err := test.enc.(StrictDecoder).DecodeStrict(s)
+			if err != nil {
+				t.Errorf("%s: %v", test.name, err)
+				continue
+			}
+			if u != u2 {
+				t.Errorf("%s: expected %q, got %q", test.name, u, u2)
+			}
+		}
+	}
+}
+
+func TestGeneratedEncodersDecoding(t *testing.T) {
+	for _, test := range generatedEncoders {
+		generic := encoder{newAlphabet(test.alphabet)}
+		abc := test.alphabet
+		inputs := []string{
+			abc[1:2],
+			abc[len(abc)-1:],
+			abc[3:15],
+			abc[len(abc)-20:],
+		}
+		for _, s := range inputs {
+			u, err := test.enc.Decode(s)
+			if err != nil {
+				t.Errorf("%s: %v", test.name, err)
+			}
+			exp, _ := generic.Decode(s)
+			if u != exp {
+				t.Errorf("%s: expected %q for %q, got %q", test.name, exp, s, u)
+			}
+			if _, err := test.enc.(StrictDecoder).DecodeStrict(s); !errors.Is(err, ErrLength) {
+				t.Errorf("%s: expected %v for %q, got %v", test.name, ErrLength, s, err)
+			}
+		}
+
+		var charErr *InvalidCharError
+		if _, err := test.enc.Decode(abc[:5] + "!"); !errors.As(err, &charErr) || charErr.Offset != 5 {
+			t.Errorf("%s: expected '!' at offset 5, got %v", test.name, err)
+		}
+		if _, err := test.enc.Decode(abc[:5] + "う"); !errors.As(err, &charErr) || charErr.Char != 'う' {
+			t.Errorf("%s: expected 'う' at offset 5, got %v", test.name, err)
+		}
+		overflow := make([]byte, test.encLen)
+		for i := range overflow {
+			overflow[i] = abc[len(abc)-1]
+		}
+		if _, err := test.enc.Decode(string(overflow)); !errors.Is(err, ErrOverflow) {
+			t.Errorf("%s: expected %v, got %v", test.name, ErrOverflow, err)
+		}
+	}
+}
+
+func BenchmarkEncodingBase62(b *testing.B) {
+	u := uuid.New()
+	for i := 0; i < b.N; i++ {
+		Base62Encoder.Encode(u)
+	}
+}
+
+func BenchmarkEncodingBase58(b *testing.B) {
+	u := uuid.New()
+	for i := 0; i < b.N; i++ {
+		Base58Encoder.Encode(u)
+	}
+}
+
+func BenchmarkEncodingBase36(b *testing.B) {
+	u := uuid.New()
+	for i := 0; i < b.N; i++ {
+		Base36Encoder.Encode(u)
+	}
+}
+
+func BenchmarkEncodingBase64URL(b *testing.B) {
+	u := uuid.New()
+	for i := 0; i < b.N; i++ {
+		Base64URLEncoder.Encode(u)
+	}
+}
+
+func BenchmarkDecodingBase62(b *testing.B) {
+	s := Base62Encoder.Encode(uuid.New())
+	for i := 0; i < b.N; i++ {
+		_, _ = Base62Encoder.Decode(s)
+	}
+}
+
+func BenchmarkDecodingBase58(b *testing.B) {
+	s := Base58Encoder.Encode(uuid.New())
+	for i := 0; i < b.N; i++ {
+		_, _ = Base58Encoder.Decode(s)
+	}
+}
+
+func BenchmarkDecodingBase36(b *testing.B) {
+	s := Base36Encoder.Encode(uuid.New())
+	for i := 0; i < b.N; i++ {
+		_, _ = Base36Encoder.Decode(s)
+	}
+}
+
+func BenchmarkDecodingBase64URL(b *testing.B) {
+	s := Base64URLEncoder.Encode(uuid.New())
+	for i := 0; i < b.N; i++ {
+		_, _ = Base64URLEncoder.Decode(s)
+	}
+}
@@ -0,0 +1,57 @@
+package shortuuid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+
+	"github.com/google/uuid"
+)
+
+// encryptingEncoder wraps an Encoder and encrypts UUIDs before encoding them.
+type encryptingEncoder struct {
+	enc   Encoder
+	block cipher.Block
+}
+
+// NewEncryptingEncoder returns an Encoder that encrypts UUIDs with AES using
+// key, which must be 16, 24 or 32 bytes long, before encoding them with enc,
+// and decrypts them again after decoding. Since AES is a permutation of 128-bit
+// blocks, the output has the same form as the output of enc, but hides the
+// contents of the UUID, such as the creation time of a UUIDv7, from anyone
+// without the key.
+//
+// The encryption doesn't authenticate the IDs: every input that enc decodes is
+// decrypted to some UUID. Encrypted IDs also don't preserve the order of the
+// UUIDs. DecodeStrict decrypts what the DecodeStrict method of enc returns, or
+// returns ErrStrictUnsupported if enc has none.
+func NewEncryptingEncoder(enc Encoder, key []byte) (Encoder, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return encryptingEncoder{enc, block}, nil
+}
+
+func (e encryptingEncoder) Encode(u uuid.UUID) string {
+	e.block.Encrypt(u[:], u[:])
+	return e.enc.Encode(u)
+}
+
+func (e encryptingEncoder) Decode(s string) (uuid.UUID, error) {
+	u, err := e.enc.Decode(s)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	e.block.Decrypt(u[:], u[:])
+	return u, nil
+}
+
+// DecodeStrict implements StrictDecoder.
+func (e encryptingEncoder) DecodeStrict(s string) (uuid.UUID, error) {
+	u, err := decodeStrict(e.enc, s)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	e.block.Decrypt(u[:], u[:])
+	return u, nil
+}
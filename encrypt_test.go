@@ -0,0 +1,73 @@
+package shortuuid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+var testEncryptionKey = []byte{
+	0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07,
+	0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+}
+
+func TestEncryptingEncoder(t *testing.T) {
+	hex, _ := NewEncoder("0123456789abcdef")
+	enc, err := NewEncryptingEncoder(hex, testEncryptionKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The AES-128 example from FIPS-197, appendix C.1.
+	u := uuid.MustParse("00112233-4455-6677-8899-aabbccddeeff")
+	exp := "69c4e0d86a7b0430d8cdb78070b4c55a"
+	if s := enc.Encode(u); s != exp {
+		t.Errorf("expected %q, got %q", exp, s)
+	}
+	if got, err := enc.Decode(exp); err != nil || got != u {
+		t.Errorf("expected %q, got %q, %v", u, got, err)
+	}
+}
+
+func TestEncryptingEncoderV7(t *testing.T) {
+	enc, _ := NewEncryptingEncoder(DefaultEncoder, testEncryptionKey)
+	for i := 0; i < 10; i++ {
+		u, _ := uuid.NewV7()
+		s := enc.Encode(u)
+		if len(s) != 22 {
+			t.Errorf("expected 22 characters, got %q", s)
+		}
+		if s == DefaultEncoder.Encode(u) {
+			t.Errorf("expected %q to be encrypted", s)
+		}
+		got, err := enc.(StrictDecoder).DecodeStrict(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != u {
+			t.Errorf("expected %q, got %q", u, got)
+		}
+	}
+}
+
+func TestEncryptingEncoderErrors(t *testing.T) {
+	if _, err := NewEncryptingEncoder(DefaultEncoder, []byte("short")); err == nil {
+		t.Error("expected an error for a 5 byte key")
+	}
+
+	enc, _ := NewEncryptingEncoder(DefaultEncoder, testEncryptionKey)
+	_, err := enc.Decode("KwSysDpxcBU9FNhGkn2dC0")
+	var charErr *InvalidCharError
+	if !errors.As(err, &charErr) || charErr.Offset != 21 {
+		t.Errorf("expected *InvalidCharError at offset 21, got %v", err)
+	}
+	if _, err := enc.(StrictDecoder).DecodeStrict("KwSys"); !errors.Is(err, ErrLength) {
+		t.Errorf("expected %v, got %v", ErrLength, err)
+	}
+
+	lenient, _ := NewEncryptingEncoder(lenientEncoder{DefaultEncoder}, testEncryptionKey)
+	if _, err := lenient.(StrictDecoder).DecodeStrict("KwSys"); !errors.Is(err, ErrStrictUnsupported) {
+		t.Errorf("expected %v, got %v", ErrStrictUnsupported, err)
+	}
+}
@@ -0,0 +1,57 @@
+package shortuuid
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidChar is returned when decoding if the input contains a
+	// character that is not part of the alphabet. Decoders report it as an
+	// *InvalidCharError, which wraps ErrInvalidChar.
+	ErrInvalidChar = errors.New("not part of the alphabet")
+
+	// ErrLength is returned by strict decoding if the input does not have
+	// exactly as many characters as an encoded UUID, and when unmarshaling
+	// an ID from a binary value that is not 16 bytes.
+	ErrLength = errors.New("invalid length")
+
+	// ErrChecksum is returned by the encoders returned by NewCheckEncoder
+	// if the check character does not match the rest of the input.
+	ErrChecksum = errors.New("check character mismatch")
+
+	// ErrSignature is returned by the encoders returned by NewSigningEncoder
+	// if the input was not signed with any of their keys, or was changed
+	// after signing.
+	ErrSignature = errors.New("invalid signature")
+
+	// ErrPrefix is returned by prefixed encoders and when parsing a TypedID
+	// if the prefix is missing, invalid or not the expected one.
+	ErrPrefix = errors.New("invalid prefix")
+
+	// ErrStrictUnsupported is returned by the DecodeStrict method of the
+	// encoders that wrap another encoder, such as the ones returned by
+	// NewNormalizingEncoder, if the wrapped encoder doesn't implement
+	// StrictDecoder.
+	ErrStrictUnsupported = errors.New("strict decoding is not supported")
+
+	// ErrOverflow is returned when decoding if the input represents a number
+	// that does not fit in 128 bits, or in 64 bits for an IntEncoder.
+	ErrOverflow = errors.New("number is out of range")
+)
+
+// InvalidCharError describes a character in the input to a decoder that is not
+// part of the alphabet.
+type InvalidCharError struct {
+	Char   rune // the offending character
+	Offset int  // byte offset of Char in the input
+}
+
+func (e *InvalidCharError) Error() string {
+	return fmt.Sprintf("element %q at offset %d is not part of the alphabet", e.Char, e.Offset)
+}
+
+// Unwrap returns ErrInvalidChar.
+func (e *InvalidCharError) Unwrap() error {
+	return ErrInvalidChar
+}
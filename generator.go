@@ -0,0 +1,155 @@
+package shortuuid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// Generator generates short UUIDs from a configurable source of randomness,
+// encoder and UUID version. The package-level functions such as New use a
+// default Generator.
+//
+// The zero value is ready to use and generates UUIDv4s from the same source as
+// uuid.New, encoded with DefaultEncoder, like NewGenerator(nil, nil, 4). A
+// Generator is safe for concurrent use if its source of randomness is.
+type Generator struct {
+	rand    io.Reader
+	enc     Encoder
+	version int
+}
+
+var (
+	defaultGenerator   = &Generator{enc: DefaultEncoder, version: 4}
+	defaultV7Generator = &Generator{enc: DefaultEncoder, version: 7}
+)
+
+// NewGenerator returns a Generator that reads randomness from r, encodes with
+// enc and generates UUIDs of the given version, which must be 4 or 7.
+//
+// If r is nil, the same source as uuid.New is used. If enc is nil,
+// DefaultEncoder is used. Note that UUIDv7 embeds the current time, so only
+// version 4 generates the same IDs for the same randomness.
+func NewGenerator(r io.Reader, enc Encoder, version int) (*Generator, error) {
+	if version != 4 && version != 7 {
+		return nil, fmt.Errorf("unsupported UUID version %d (must be 4 or 7)", version)
+	}
+	if enc == nil {
+		enc = DefaultEncoder
+	}
+	return &Generator{rand: r, enc: enc, version: version}, nil
+}
+
+// NewUUID returns a new UUID of the Generator's version, or an error if the
+// source of randomness fails.
+func (g *Generator) NewUUID() (uuid.UUID, error) {
+	switch {
+	case g.version == 7 && g.rand == nil:
+		return uuid.NewV7()
+	case g.version == 7:
+		return uuid.NewV7FromReader(g.rand)
+	case g.rand == nil:
+		return uuid.NewRandom()
+	default:
+		return uuid.NewRandomFromReader(g.rand)
+	}
+}
+
+// mustNewUUID is like NewUUID but panics if the source of randomness fails,
+// like uuid.New.
+func (g *Generator) mustNewUUID() uuid.UUID {
+	return uuid.Must(g.NewUUID())
+}
+
+// New returns a new UUID, encoded with the Generator's encoder. Panics if the
+// source of randomness fails.
+func (g *Generator) New() string {
+	return g.encoder().Encode(g.mustNewUUID())
+}
+
+// NewWithNamespace returns a new UUIDv5 (or a UUID of the Generator's version
+// if name is empty), encoded with the Generator's encoder. Like the
+// package-level NewWithNamespace, names starting with http:// or https:// are
+// hashed in the URL namespace and other names in the DNS namespace.
+func (g *Generator) NewWithNamespace(name string) string {
+	var u uuid.UUID
+
+	switch {
+	case name == "":
+		u = g.mustNewUUID()
+	case hasPrefixCaseInsensitive(name, "https://"):
+		u = hashedUUID(uuid.NameSpaceURL, name)
+	case hasPrefixCaseInsensitive(name, "http://"):
+		u = hashedUUID(uuid.NameSpaceURL, name)
+	default:
+		u = hashedUUID(uuid.NameSpaceDNS, name)
+	}
+
+	return g.encoder().Encode(u)
+}
+
+// Random returns a random string of length characters, like the package-level
+// Random. The characters are drawn from the alphabet of the Generator's
+// encoder if it has one, and from DefaultAlphabet otherwise. Panics if length
+// is negative or if the source of randomness fails.
+func (g *Generator) Random(length int) string {
+	a := &defaultAlphabet
+	if ae, ok := g.encoder().(alphabetEncoder); ok {
+		a = ae.alpha()
+	}
+	return randomWithAlphabet(g.randReader(), a, length)
+}
+
+// encoder returns the Generator's encoder, or DefaultEncoder if it doesn't
+// have one.
+func (g *Generator) encoder() Encoder {
+	if g.enc == nil {
+		return DefaultEncoder
+	}
+	return g.enc
+}
+
+// randReader returns the Generator's source of randomness, or crypto/rand if
+// it doesn't have one.
+func (g *Generator) randReader() io.Reader {
+	if g.rand == nil {
+		return rand.Reader
+	}
+	return g.rand
+}
+
+// NewSeededReader returns a deterministic source of randomness for tests,
+// which produces the same stream of bytes for the same seed. Use it with
+// NewGenerator to get reproducible IDs.
+//
+// The stream is not cryptographically secure and must not be used outside of
+// tests.
+func NewSeededReader(seed uint64) io.Reader {
+	return &seededReader{state: seed}
+}
+
+// seededReader is a SplitMix64 pseudo-random number generator.
+type seededReader struct {
+	state uint64
+	buf   [8]byte
+	n     int // number of unread bytes at the end of buf
+}
+
+func (r *seededReader) Read(p []byte) (int, error) {
+	for i := range p {
+		if r.n == 0 {
+			r.state += 0x9e3779b97f4a7c15
+			z := r.state
+			z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+			z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+			binary.BigEndian.PutUint64(r.buf[:], z^(z>>31))
+			r.n = len(r.buf)
+		}
+		p[i] = r.buf[len(r.buf)-r.n]
+		r.n--
+	}
+	return len(p), nil
+}
@@ -0,0 +1,117 @@
+package shortuuid
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestGeneratorDeterministic(t *testing.T) {
+	g1, err := NewGenerator(NewSeededReader(42), nil, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g2, _ := NewGenerator(NewSeededReader(42), nil, 4)
+	g3, _ := NewGenerator(NewSeededReader(43), nil, 4)
+	for i := 0; i < 10; i++ {
+		s1, s2, s3 := g1.New(), g2.New(), g3.New()
+		if s1 != s2 {
+			t.Errorf("expected generators with the same seed to agree, got %q and %q", s1, s2)
+		}
+		if s1 == s3 {
+			t.Errorf("expected generators with different seeds to differ, got %q", s1)
+		}
+		u, err := DefaultEncoder.Decode(s1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Version() != 4 || u.Variant() != uuid.RFC4122 {
+			t.Errorf("expected a RFC 4122 UUIDv4, got %q", u)
+		}
+	}
+}
+
+func TestGeneratorSeededStream(t *testing.T) {
+	// The stream must stay stable so that tests relying on it don't break.
+	g, _ := NewGenerator(NewSeededReader(1), nil, 4)
+	exp := []string{"TovXSXPu2s7in2FT5JqWmt", "nEwgRRcn9dEpghpGEuBQpd"}
+	for _, e := range exp {
+		if s := g.New(); s != e {
+			t.Errorf("expected %q, got %q", e, s)
+		}
+	}
+}
+
+func TestGeneratorV7(t *testing.T) {
+	enc, _ := NewEncoder("0123456789abcdef")
+	g, err := NewGenerator(NewSeededReader(1), enc, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := g.NewUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Version() != 7 {
+		t.Errorf("expected UUID version 7, got %d", u.Version())
+	}
+	if s := g.New(); len(s) != 32 {
+		t.Errorf("expected a hex encoded UUID, got %q", s)
+	}
+}
+
+func TestGeneratorNewWithNamespace(t *testing.T) {
+	g, _ := NewGenerator(NewSeededReader(1), nil, 4)
+	if s := g.NewWithNamespace("http://www.example.com/"); s != "nzUQAfy7CW4Dd4kzLguPSV" {
+		t.Errorf("expected %q, got %q", "nzUQAfy7CW4Dd4kzLguPSV", s)
+	}
+	g2, _ := NewGenerator(NewSeededReader(1), nil, 4)
+	if s1, s2 := g.NewWithNamespace(""), g2.New(); s1 != s2 {
+		t.Errorf("expected %q, got %q", s2, s1)
+	}
+}
+
+func TestGeneratorRandom(t *testing.T) {
+	enc, _ := NewEncoder("0123456789abcdef")
+	g1, _ := NewGenerator(NewSeededReader(1), enc, 4)
+	g2, _ := NewGenerator(NewSeededReader(1), enc, 4)
+	s1, s2 := g1.Random(16), g2.Random(16)
+	if s1 != s2 {
+		t.Errorf("expected %q, got %q", s1, s2)
+	}
+	for _, c := range s1 {
+		if _, err := enc.(alphabetEncoder).alpha().Index(c); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestGeneratorZeroValue(t *testing.T) {
+	var g Generator
+	u, err := DefaultEncoder.DecodeStrict(g.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Version() != 4 {
+		t.Errorf("expected UUID version 4, got %d", u.Version())
+	}
+	if s := g.NewWithNamespace("http://www.example.com/"); s != "nzUQAfy7CW4Dd4kzLguPSV" {
+		t.Errorf("expected %q, got %q", "nzUQAfy7CW4Dd4kzLguPSV", s)
+	}
+	if ids := g.NewBatch(2); len(ids) != 2 || len(ids[0]) != 22 {
+		t.Errorf("expected 2 base57 IDs, got %q", ids)
+	}
+	for _, c := range g.Random(16) {
+		if _, err := defaultAlphabet.Index(c); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestNewGeneratorErrors(t *testing.T) {
+	for _, version := range []int{0, 1, 3, 5, 6, 8} {
+		if _, err := NewGenerator(nil, nil, version); err == nil {
+			t.Errorf("expected an error for version %d", version)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package shortuuid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// groupingEncoder wraps an Encoder and splits its output into groups of
+// characters for readability.
+type groupingEncoder struct {
+	enc  Encoder
+	size int
+	sep  string
+}
+
+// NewGroupingEncoder returns an Encoder that splits the output of enc into
+// groups of size characters joined by sep, e.g. KwSys-DpxcB-U9FNh-Gkn2d-Cf for
+// DefaultEncoder with a size of 5 and "-" as separator. Decode accepts both
+// the grouped and the plain form, since all occurrences of sep are removed
+// before decoding.
+//
+// If enc is built on an alphabet, like DefaultEncoder, Base62Encoder and the
+// other generated encoders, LegacyPythonEncoder, and the encoders returned by
+// NewEncoder and NewLegacyPythonEncoder, an error is returned if sep contains
+// characters from its alphabet. The returned Encoder
+// implements StrictDecoder by removing the separators and passing the rest to
+// the DecodeStrict method of enc, and returns ErrStrictUnsupported if enc has
+// none.
+func NewGroupingEncoder(enc Encoder, size int, sep string) (Encoder, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("invalid group size %d", size)
+	}
+	if sep == "" {
+		return nil, errors.New("group separator must not be empty")
+	}
+	if ae, ok := enc.(alphabetEncoder); ok {
+		for _, c := range sep {
+			if _, err := ae.alpha().Index(c); err == nil {
+				return nil, fmt.Errorf("group separator character %q is part of the alphabet", c)
+			}
+		}
+	}
+	return groupingEncoder{enc, size, sep}, nil
+}
+
+func (e groupingEncoder) Encode(u uuid.UUID) string {
+	s := e.enc.Encode(u)
+	n := utf8.RuneCountInString(s)
+	if n <= e.size {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s) + (n-1)/e.size*len(e.sep))
+	start, i := 0, 0
+	for j := range s {
+		if i > 0 && i%e.size == 0 {
+			b.WriteString(s[start:j])
+			b.WriteString(e.sep)
+			start = j
+		}
+		i++
+	}
+	b.WriteString(s[start:])
+	return b.String()
+}
+
+func (e groupingEncoder) Decode(s string) (uuid.UUID, error) {
+	u, err := e.enc.Decode(strings.ReplaceAll(s, e.sep, ""))
+	return u, e.fixOffset(s, err)
+}
+
+// DecodeStrict implements StrictDecoder.
+func (e groupingEncoder) DecodeStrict(s string) (uuid.UUID, error) {
+	u, err := decodeStrict(e.enc, strings.ReplaceAll(s, e.sep, ""))
+	return u, e.fixOffset(s, err)
+}
+
+// fixOffset translates the offset of an *InvalidCharError from s with all
+// separators removed back to s itself.
+func (e groupingEncoder) fixOffset(s string, err error) error {
+	var charErr *InvalidCharError
+	if !errors.As(err, &charErr) {
+		return err
+	}
+	pos := 0
+	for i := 0; i < len(s); {
+		if strings.HasPrefix(s[i:], e.sep) {
+			i += len(e.sep)
+			continue
+		}
+		if pos == charErr.Offset {
+			return &InvalidCharError{Char: charErr.Char, Offset: i}
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		pos += size
+	}
+	return err
+}
@@ -0,0 +1,81 @@
+package shortuuid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestGroupingEncoder(t *testing.T) {
+	u := uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f")
+	hex, _ := NewEncoder("0123456789abcdef")
+	mb, _ := NewEncoder("うえおなにぬねのウエオナニヌネノ")
+	tests := []struct {
+		enc  Encoder
+		size int
+		sep  string
+		exp  string
+	}{
+		{DefaultEncoder, 5, "-", "KwSys-DpxcB-U9FNh-Gkn2d-Cf"},
+		{DefaultEncoder, 11, " ", "KwSysDpxcBU 9FNhGkn2dCf"},
+		{DefaultEncoder, 22, "-", "KwSysDpxcBU9FNhGkn2dCf"},
+		{DefaultEncoder, 4, " - ", "KwSy - sDpx - cBU9 - FNhG - kn2d - Cf"},
+		{hex, 8, "-", "64d1355f-d0524bd9-83f439b9-3fb1c01f"},
+		{mb, 8, "・", "ねにヌえなぬぬノ・ヌうぬおにナヌエ・ウなノになエナエ・なノナえニうえノ"},
+	}
+	for _, test := range tests {
+		enc, err := NewGroupingEncoder(test.enc, test.size, test.sep)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := enc.Encode(u)
+		if s != test.exp {
+			t.Errorf("expected %q, got %q", test.exp, s)
+		}
+		for _, s := range []string{s, test.enc.Encode(u)} {
+			u2, err := enc.Decode(s)
+			if err != nil {
+				t.Errorf("unexpected error decoding %q: %v", s, err)
+				continue
+			}
+			if u != u2 {
+				t.Errorf("expected %q, got %q", u, u2)
+			}
+		}
+	}
+}
+
+func TestGroupingEncoderDecodeErrors(t *testing.T) {
+	enc, err := NewGroupingEncoder(DefaultEncoder, 5, "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var charErr *InvalidCharError
+	_, err = enc.Decode("KwSys-DpxcB-U9FNh-Gkn2d-C0")
+	if !errors.As(err, &charErr) || charErr.Char != '0' || charErr.Offset != 25 {
+		t.Errorf("expected '0' at offset 25, got %v", err)
+	}
+	_, err = enc.(StrictDecoder).DecodeStrict("KwSys-DpxcB-U9FNh-Gkn2d")
+	if !errors.Is(err, ErrLength) {
+		t.Errorf("expected %v, got %v", ErrLength, err)
+	}
+
+	lenient, _ := NewGroupingEncoder(lenientEncoder{DefaultEncoder}, 5, "-")
+	_, err = lenient.(StrictDecoder).DecodeStrict("3")
+	if !errors.Is(err, ErrStrictUnsupported) {
+		t.Errorf("expected %v, got %v", ErrStrictUnsupported, err)
+	}
+}
+
+func TestNewGroupingEncoderErrors(t *testing.T) {
+	if _, err := NewGroupingEncoder(DefaultEncoder, 0, "-"); err == nil {
+		t.Error("expected an error for group size 0")
+	}
+	if _, err := NewGroupingEncoder(DefaultEncoder, 5, ""); err == nil {
+		t.Error("expected an error for an empty separator")
+	}
+	if _, err := NewGroupingEncoder(DefaultEncoder, 5, "a"); err == nil {
+		t.Error("expected an error for a separator from the alphabet")
+	}
+}
@@ -0,0 +1,131 @@
+package shortuuid
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ID is a UUID that is represented in its short base57 form when formatted or
+// marshaled as text. The binary representation is the raw 16 bytes of the
+// UUID.
+//
+// The zero value is the nil UUID.
+type ID uuid.UUID
+
+// Parse decodes the base57 string s into an ID. Since IDs are often supplied
+// by users, only the canonical form is accepted: ErrLength is returned if s is
+// not exactly 22 characters long.
+func Parse(s string) (ID, error) {
+	u, err := DefaultEncoder.DecodeStrict(s)
+	return ID(u), err
+}
+
+// UUID returns id as a uuid.UUID.
+func (id ID) UUID() uuid.UUID {
+	return uuid.UUID(id)
+}
+
+// String returns the base57 representation of id.
+func (id ID) String() string {
+	return DefaultEncoder.Encode(uuid.UUID(id))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id ID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Like Parse, it only
+// accepts the canonical form.
+func (id *ID) UnmarshalText(data []byte) error {
+	u, err := DefaultEncoder.DecodeStrict(string(data))
+	if err != nil {
+		return err
+	}
+	*id = ID(u)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (id ID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(id.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves id unchanged.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return id.UnmarshalText([]byte(s))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (id ID) MarshalBinary() ([]byte, error) {
+	return id[:], nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (id *ID) UnmarshalBinary(data []byte) error {
+	if len(data) != len(id) {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrLength, len(id), len(data))
+	}
+	copy(id[:], data)
+	return nil
+}
+
+// Scan implements sql.Scanner. It accepts a canonical UUID string, the raw 16
+// bytes of a UUID (e.g. from a BINARY(16) column) or a base57 string. A NULL
+// value leaves id unchanged.
+func (id *ID) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return id.scanText(src)
+	case []byte:
+		if len(src) == len(id) {
+			copy(id[:], src)
+			return nil
+		}
+		return id.scanText(string(src))
+	default:
+		return fmt.Errorf("unable to scan type %T into ID", src)
+	}
+}
+
+func (id *ID) scanText(s string) error {
+	if s == "" {
+		return nil
+	}
+	if u, err := uuid.Parse(s); err == nil {
+		*id = ID(u)
+		return nil
+	}
+	return id.UnmarshalText([]byte(s))
+}
+
+// Value implements driver.Valuer. It writes id as a canonical UUID string,
+// which is suitable for native UUID columns. Use ShortValue to write the
+// base57 form instead.
+func (id ID) Value() (driver.Value, error) {
+	return uuid.UUID(id).String(), nil
+}
+
+// ShortValue returns a driver.Valuer that writes id in its base57 form, for
+// storing IDs in text columns.
+func (id ID) ShortValue() driver.Valuer {
+	return shortValue(id)
+}
+
+type shortValue ID
+
+func (v shortValue) Value() (driver.Value, error) {
+	return ID(v).String(), nil
+}
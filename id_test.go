@@ -0,0 +1,169 @@
+package shortuuid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParse(t *testing.T) {
+	for _, test := range testVector {
+		id, err := Parse(test.shortuuid)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if id.UUID() != uuid.MustParse(test.uuid) {
+			t.Errorf("expected %q, got %q", test.uuid, id.UUID())
+		}
+		if id.String() != test.shortuuid {
+			t.Errorf("expected %q, got %q", test.shortuuid, id.String())
+		}
+	}
+}
+
+func TestParseNonCanonical(t *testing.T) {
+	for _, s := range []string{"", "2", "22", "KwSysDpxcBU9FNhGkn2dCf2"} {
+		if _, err := Parse(s); !errors.Is(err, ErrLength) {
+			t.Errorf("expected %v for %q, got %v", ErrLength, s, err)
+		}
+		var id ID
+		if err := id.UnmarshalText([]byte(s)); !errors.Is(err, ErrLength) {
+			t.Errorf("expected %v for %q, got %v", ErrLength, s, err)
+		}
+	}
+}
+
+func TestIDText(t *testing.T) {
+	id := ID(uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f"))
+	b, err := id.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "KwSysDpxcBU9FNhGkn2dCf" {
+		t.Errorf("expected %q, got %q", "KwSysDpxcBU9FNhGkn2dCf", b)
+	}
+	var id2 ID
+	if err := id2.UnmarshalText(b); err != nil {
+		t.Fatal(err)
+	}
+	if id != id2 {
+		t.Errorf("expected %q, got %q", id, id2)
+	}
+	if err := id2.UnmarshalText([]byte("0lIO")); err == nil {
+		t.Error("expected an error for invalid characters")
+	}
+}
+
+func TestIDJSON(t *testing.T) {
+	type payload struct {
+		ID  ID  `json:"id"`
+		Ptr *ID `json:"ptr"`
+	}
+	id := ID(uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f"))
+	b, err := json.Marshal(payload{ID: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `{"id":"KwSysDpxcBU9FNhGkn2dCf","ptr":null}`
+	if string(b) != exp {
+		t.Errorf("expected %s, got %s", exp, b)
+	}
+	var p payload
+	if err := json.Unmarshal(b, &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != id || p.Ptr != nil {
+		t.Errorf("expected %q, got %q", id, p.ID)
+	}
+	if err := json.Unmarshal([]byte(`{"id":42}`), &p); err == nil {
+		t.Error("expected an error for a non-string ID")
+	}
+}
+
+func TestIDBinary(t *testing.T) {
+	u := uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f")
+	id := ID(u)
+	b, err := id.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, u[:]) {
+		t.Errorf("expected %x, got %x", u[:], b)
+	}
+	var id2 ID
+	if err := id2.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if id != id2 {
+		t.Errorf("expected %q, got %q", id, id2)
+	}
+	if err := id2.UnmarshalBinary(b[1:]); err == nil {
+		t.Error("expected an error for a short buffer")
+	}
+}
+
+func TestIDScan(t *testing.T) {
+	u := uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f")
+	tests := []any{
+		"64d1355f-d052-4bd9-83f4-39b93fb1c01f",
+		[]byte("64d1355f-d052-4bd9-83f4-39b93fb1c01f"),
+		"64d1355fd0524bd983f439b93fb1c01f",
+		u[:],
+		"KwSysDpxcBU9FNhGkn2dCf",
+		[]byte("KwSysDpxcBU9FNhGkn2dCf"),
+	}
+	for _, src := range tests {
+		var id ID
+		if err := id.Scan(src); err != nil {
+			t.Errorf("unexpected error scanning %v: %v", src, err)
+			continue
+		}
+		if id.UUID() != u {
+			t.Errorf("expected %q, got %q", u, id.UUID())
+		}
+	}
+}
+
+func TestIDScanNull(t *testing.T) {
+	id := ID(uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f"))
+	for _, src := range []any{nil, ""} {
+		id2 := id
+		if err := id2.Scan(src); err != nil {
+			t.Error(err)
+		}
+		if id2 != id {
+			t.Errorf("expected %q, got %q", id, id2)
+		}
+	}
+}
+
+func TestIDScanErrors(t *testing.T) {
+	for _, src := range []any{42, "not-an-id", []byte("0lIO")} {
+		var id ID
+		if err := id.Scan(src); err == nil {
+			t.Errorf("expected an error scanning %v", src)
+		}
+	}
+}
+
+func TestIDValue(t *testing.T) {
+	id := ID(uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f"))
+	v, err := id.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != "64d1355f-d052-4bd9-83f4-39b93fb1c01f" {
+		t.Errorf("expected %q, got %q", "64d1355f-d052-4bd9-83f4-39b93fb1c01f", v)
+	}
+	v, err = id.ShortValue().Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != "KwSysDpxcBU9FNhGkn2dCf" {
+		t.Errorf("expected %q, got %q", "KwSysDpxcBU9FNhGkn2dCf", v)
+	}
+}
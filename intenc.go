@@ -0,0 +1,138 @@
+package shortuuid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// feistelRounds is the number of rounds of the Feistel network used by
+// IntEncoder. Four rounds with a pseudorandom round function already give a
+// strong pseudorandom permutation; the rest is margin.
+const feistelRounds = 8
+
+// IntEncoder maps 64-bit integers, such as sequential database keys, to
+// opaque fixed-length strings and back. The integers are permuted with a
+// Feistel network keyed by AES before being encoded, so consecutive integers
+// give unrelated strings, and the sequence can't be recovered without the
+// key.
+//
+// The same key and alphabet always give the same strings. An IntEncoder hides
+// the integers, but doesn't authenticate the strings: every string of the
+// right length that decodes to a 64-bit number is accepted.
+//
+// An IntEncoder is safe for concurrent use.
+type IntEncoder struct {
+	block    cipher.Block
+	alphabet alphabet
+	encLen   int // number of characters in an encoded integer
+}
+
+// NewIntEncoder returns an IntEncoder that permutes integers with key, which
+// must be 16, 24 or 32 bytes long, and encodes them with the alphabet abc,
+// such as DefaultAlphabet. Like NewEncoder, the alphabet is sorted and
+// deduplicated, and must have at least 2 characters.
+func NewIntEncoder(key []byte, abc string) (*IntEncoder, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	a, err := parseAlphabet(abc)
+	if err != nil {
+		return nil, err
+	}
+	return &IntEncoder{
+		block:    block,
+		alphabet: a,
+		encLen:   int(math.Ceil(64 / math.Log2(float64(a.len)))),
+	}, nil
+}
+
+// EncodeUint64 returns the opaque encoded form of v.
+func (e *IntEncoder) EncodeUint64(v uint64) string {
+	num := uint128{Lo: e.permute(v)}
+	var digits [64]int32
+	var r uint64
+	for i := e.encLen - 1; i >= 0; i-- {
+		num, r = num.quoRem64(uint64(e.alphabet.len))
+		digits[i] = int32(r)
+	}
+
+	buf := make([]byte, 0, e.encLen*int(e.alphabet.maxBytes))
+	for _, d := range digits[:e.encLen] {
+		buf = utf8.AppendRune(buf, e.alphabet.chars[d])
+	}
+	return unsafe.String(unsafe.SliceData(buf), len(buf))
+}
+
+// DecodeUint64 returns the integer that s is the encoded form of.
+//
+// It returns ErrLength if s doesn't have exactly as many characters as an
+// encoded integer, an *InvalidCharError if s contains a character that is not
+// part of the alphabet, and ErrOverflow if s represents a number that does not
+// fit in 64 bits.
+func (e *IntEncoder) DecodeUint64(s string) (uint64, error) {
+	if n := utf8.RuneCountInString(s); n != e.encLen {
+		return 0, fmt.Errorf("%w: expected %d characters, got %d", ErrLength, e.encLen, n)
+	}
+
+	var num uint128
+	for j, c := range s {
+		index, err := e.alphabet.Index(c)
+		if err != nil {
+			return 0, &InvalidCharError{Char: c, Offset: j}
+		}
+		num, err = num.mulAdd64(uint64(e.alphabet.len), uint64(index))
+		if err != nil {
+			return 0, err
+		}
+	}
+	if num.Hi != 0 {
+		return 0, ErrOverflow
+	}
+	return e.unpermute(num.Lo), nil
+}
+
+// EncodeInt64 is like EncodeUint64 but for signed integers. Negative
+// integers are encoded as their two's complement.
+func (e *IntEncoder) EncodeInt64(v int64) string {
+	return e.EncodeUint64(uint64(v))
+}
+
+// DecodeInt64 is like DecodeUint64 but for signed integers.
+func (e *IntEncoder) DecodeInt64(s string) (int64, error) {
+	v, err := e.DecodeUint64(s)
+	return int64(v), err
+}
+
+// permute applies the keyed Feistel network to v.
+func (e *IntEncoder) permute(v uint64) uint64 {
+	l, r := uint32(v>>32), uint32(v)
+	for i := 0; i < feistelRounds; i++ {
+		l, r = r, l^e.round(i, r)
+	}
+	return uint64(l)<<32 | uint64(r)
+}
+
+// unpermute is the inverse of permute.
+func (e *IntEncoder) unpermute(v uint64) uint64 {
+	l, r := uint32(v>>32), uint32(v)
+	for i := feistelRounds - 1; i >= 0; i-- {
+		l, r = r^e.round(i, l), l
+	}
+	return uint64(l)<<32 | uint64(r)
+}
+
+// round is the round function of the Feistel network. It encrypts the round
+// number and x with AES, which makes it a pseudorandom function of both.
+func (e *IntEncoder) round(i int, x uint32) uint32 {
+	var b [aes.BlockSize]byte
+	b[0] = byte(i)
+	binary.BigEndian.PutUint32(b[aes.BlockSize-4:], x)
+	e.block.Encrypt(b[:], b[:])
+	return binary.BigEndian.Uint32(b[:])
+}
@@ -0,0 +1,114 @@
+package shortuuid
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+var testIntKey = []byte("0123456789abcdef")
+
+func TestIntEncoder(t *testing.T) {
+	enc, err := NewIntEncoder(testIntKey, DefaultAlphabet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The output must stay stable, since it is stored and shared.
+	tests := []struct {
+		v uint64
+		s string
+	}{
+		{0, "ZCxLjJWomtv"},
+		{1, "ZwNwnfumv4T"},
+		{2, "cpmpTEuvpy7"},
+		{1 << 63, "gVRGf3wNmCD"},
+		{math.MaxUint64, "R4rTmdMMotN"},
+	}
+	for _, test := range tests {
+		if s := enc.EncodeUint64(test.v); s != test.s {
+			t.Errorf("expected %q, got %q", test.s, s)
+		}
+		v, err := enc.DecodeUint64(test.s)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if v != test.v {
+			t.Errorf("expected %d, got %d", test.v, v)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for v := int64(-1000); v < 1000; v++ {
+		s := enc.EncodeInt64(v)
+		if len(s) != 11 {
+			t.Errorf("expected 11 characters, got %q", s)
+		}
+		if seen[s] {
+			t.Errorf("expected unique strings, got %q twice", s)
+		}
+		seen[s] = true
+		got, err := enc.DecodeInt64(s)
+		if err != nil || got != v {
+			t.Errorf("expected %d, got %d, %v", v, got, err)
+		}
+	}
+}
+
+func TestIntEncoderKeys(t *testing.T) {
+	enc1, _ := NewIntEncoder(testIntKey, DefaultAlphabet)
+	enc2, _ := NewIntEncoder([]byte("fedcba9876543210"), DefaultAlphabet)
+	s := enc1.EncodeUint64(42)
+	if s == enc2.EncodeUint64(42) {
+		t.Errorf("expected different keys to give different strings, got %q", s)
+	}
+	if v, err := enc2.DecodeUint64(s); err == nil && v == 42 {
+		t.Error("expected a different key to decode to a different integer")
+	}
+
+	hex, _ := NewIntEncoder(testIntKey, "0123456789abcdef")
+	if s := hex.EncodeUint64(1); s != "a07474b97bc43f6b" {
+		t.Errorf("expected %q, got %q", "a07474b97bc43f6b", s)
+	}
+	multibyte, _ := NewIntEncoder(testIntKey, "うえおなにぬねのウエオナニヌネノ")
+	if v, err := multibyte.DecodeUint64(multibyte.EncodeUint64(1)); err != nil || v != 1 {
+		t.Errorf("expected 1, got %d, %v", v, err)
+	}
+}
+
+func TestNewIntEncoderErrors(t *testing.T) {
+	if _, err := NewIntEncoder([]byte("short"), DefaultAlphabet); err == nil {
+		t.Error("expected an error for a 5 byte key")
+	}
+	if _, err := NewIntEncoder(testIntKey, "a"); !errors.Is(err, errAlphabetTooShort) {
+		t.Errorf("expected %v, got %v", errAlphabetTooShort, err)
+	}
+}
+
+func TestIntEncoderDecodeErrors(t *testing.T) {
+	enc, _ := NewIntEncoder(testIntKey, DefaultAlphabet)
+	tests := []struct {
+		s   string
+		err error
+	}{
+		{"", ErrLength},
+		{"ZCxLjJWomt", ErrLength},
+		{"ZCxLjJWomtvv", ErrLength},
+		{"ZCxLjJWomt0", ErrInvalidChar},
+		{"zzzzzzzzzzz", ErrOverflow},
+	}
+	for _, test := range tests {
+		_, err := enc.DecodeUint64(test.s)
+		if !errors.Is(err, test.err) {
+			t.Errorf("expected %v for %q, got %v", test.err, test.s, err)
+		}
+	}
+}
+
+func BenchmarkIntEncoder(b *testing.B) {
+	enc, _ := NewIntEncoder(testIntKey, DefaultAlphabet)
+	for i := 0; i < b.N; i++ {
+		enc.DecodeUint64(enc.EncodeUint64(uint64(i)))
+	}
+}
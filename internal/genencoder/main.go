@@ -0,0 +1,292 @@
+// Command genencoder generates an unrolled encoder, like b57Encoder, for a
+// fixed ASCII alphabet.
+//
+// Usage:
+//
+//	genencoder -name Base62 -type b62Encoder -alphabet 0123...xyz -o b62_gen.go
+//
+// The generated file declares the constant <name>Alphabet and the type, which
+// implements Encoder, AppendEncoder and StrictDecoder. Like newAlphabet, the
+// alphabet is sorted and deduplicated first.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"math"
+	"math/bits"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("genencoder: ")
+
+	name := flag.String("name", "", "prefix of the generated alphabet constant, e.g. Base62")
+	typ := flag.String("type", "", "name of the generated encoder type, e.g. b62Encoder")
+	abc := flag.String("alphabet", "", "characters of the alphabet")
+	out := flag.String("o", "", "output file (default standard output)")
+	flag.Parse()
+	if *name == "" || *typ == "" || *abc == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	src, err := generate(*name, *typ, *abc)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = os.WriteFile(*out, src, 0o644)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// params holds everything the template needs to render an encoder.
+type params struct {
+	Name     string
+	Type     string
+	Short    string // Type without the Encoder suffix, e.g. b62
+	Alphabet string
+	Base     uint64
+	EncLen   int    // number of digits in an encoded UUID
+	PowN     int    // number of digits that fit in a uint64
+	Pow      uint64 // Base^PowN
+	Pows     []uint64
+	Chunks   [][]int // buffer indexes filled by each uint64 chunk, last first
+	Rest     []int   // buffer indexes filled by what remains after the chunks
+	Reverse  []byte
+}
+
+// generate returns the formatted source of an encoder for the alphabet abc.
+func generate(name, typ, abc string) ([]byte, error) {
+	chars := []byte(abc)
+	for _, c := range chars {
+		if c < 0x20 || c >= 0x7f {
+			return nil, fmt.Errorf("alphabet must only contain printable ASCII characters, got %q", abc)
+		}
+	}
+	slices.Sort(chars)
+	chars = slices.Compact(chars)
+	if len(chars) < 2 {
+		return nil, fmt.Errorf("alphabet must be at least two characters, got %q", abc)
+	}
+
+	p := params{
+		Name:     name,
+		Type:     typ,
+		Short:    strings.TrimSuffix(typ, "Encoder"),
+		Alphabet: string(chars),
+		Base:     uint64(len(chars)),
+		EncLen:   int(math.Ceil(128 / math.Log2(float64(len(chars))))),
+	}
+	p.Pow, p.PowN = p.Base, 1
+	for {
+		hi, lo := bits.Mul64(p.Pow, p.Base)
+		if hi != 0 {
+			break
+		}
+		p.Pow = lo
+		p.PowN++
+	}
+	pow := uint64(1)
+	for i := 0; i < p.PowN; i++ {
+		p.Pows = append(p.Pows, pow)
+		pow *= p.Base
+	}
+
+	// Split the buffer into chunks of PowN digits from the end, leaving
+	// between 1 and PowN digits at the start for the final remainder.
+	i := p.EncLen
+	for n := (p.EncLen - 1) / p.PowN; n > 0; n-- {
+		var chunk []int
+		for j := 0; j < p.PowN; j++ {
+			i--
+			chunk = append(chunk, i)
+		}
+		p.Chunks = append(p.Chunks, chunk)
+	}
+	for i > 0 {
+		i--
+		p.Rest = append(p.Rest, i)
+	}
+
+	p.Reverse = bytes.Repeat([]byte{255}, 256)
+	for i, c := range chars {
+		p.Reverse[c] = byte(i)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, p); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+var tmpl = template.Must(template.New("encoder").Funcs(template.FuncMap{
+	"last": func(s []int) int { return s[len(s)-1] },
+	"init": func(s []int) []int { return s[:len(s)-1] },
+	"table": func(b []byte) string {
+		var sb strings.Builder
+		for i := 0; i < len(b); i += 8 {
+			sb.WriteString("\t")
+			for j, v := range b[i : i+8] {
+				if j > 0 {
+					sb.WriteString(" ")
+				}
+				fmt.Fprintf(&sb, "%d,", v)
+			}
+			sb.WriteString("\n")
+		}
+		return sb.String()
+	},
+}).Parse(`// Code generated by genencoder -name {{.Name}} -type {{.Type}}; DO NOT EDIT.
+
+package shortuuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/google/uuid"
+)
+
+// {{.Name}}Alphabet is the alphabet used by {{.Name}}Encoder.
+const {{.Name}}Alphabet = {{printf "%q" .Alphabet}}
+
+const (
+	{{.Short}}MaxU64Digits  = {{.PowN}}
+	{{.Short}}MaxU64Divisor = {{.Pow}} // {{.Base}}^{{.PowN}}
+)
+
+var {{.Short}}Alphabet = newAlphabet({{.Name}}Alphabet)
+
+// {{.Type}} is an unrolled encoder for {{.Name}}Alphabet, generated like
+// b57Encoder. Encoded UUIDs are always {{.EncLen}} characters long.
+type {{.Type}} struct{}
+
+func (e {{.Type}}) alpha() *alphabet {
+	return &{{.Short}}Alphabet
+}
+
+func (e {{.Type}}) Encode(u uuid.UUID) string {
+	var buf [{{.EncLen}}]byte
+	e.encode(&buf, u)
+	return unsafe.String(unsafe.SliceData(buf[:]), {{.EncLen}})
+}
+
+// AppendEncode appends the encoded form of u to dst and returns the extended
+// buffer. It does not allocate if dst has enough capacity.
+func (e {{.Type}}) AppendEncode(dst []byte, u uuid.UUID) []byte {
+	var buf [{{.EncLen}}]byte
+	e.encode(&buf, u)
+	return append(dst, buf[:]...)
+}
+
+// EncodeTo writes the encoded form of u to dst and returns the number of bytes
+// written, which is always {{.EncLen}}. It returns io.ErrShortBuffer if dst is too
+// small, in which case nothing is written.
+func (e {{.Type}}) EncodeTo(dst []byte, u uuid.UUID) (int, error) {
+	if len(dst) < {{.EncLen}} {
+		return 0, io.ErrShortBuffer
+	}
+	e.encode((*[{{.EncLen}}]byte)(dst), u)
+	return {{.EncLen}}, nil
+}
+
+func (e {{.Type}}) encode(buf *[{{.EncLen}}]byte, u uuid.UUID) {
+	num := uint128{
+		binary.BigEndian.Uint64(u[8:]),
+		binary.BigEndian.Uint64(u[:8]),
+	}
+	var r uint64
+{{- range .Chunks}}
+	num, r = num.quoRem64({{$.Short}}MaxU64Divisor)
+{{- range init .}}
+	buf[{{.}}], r = {{$.Name}}Alphabet[r%{{$.Base}}], r/{{$.Base}}
+{{- end}}
+	buf[{{last .}}] = {{$.Name}}Alphabet[r]
+{{- end}}
+	r = num.Lo
+{{- range init .Rest}}
+	buf[{{.}}], r = {{$.Name}}Alphabet[r%{{$.Base}}], r/{{$.Base}}
+{{- end}}
+	buf[{{last .Rest}}] = {{$.Name}}Alphabet[r]
+}
+
+// Decode decodes a string according to {{.Name}}Alphabet into a uuid.UUID. Like
+// encoder.Decode, short inputs are padded with 0 (zero) and the same errors
+// are returned.
+func (e {{.Type}}) Decode(s string) (u uuid.UUID, err error) {
+	var n uint128
+	var n64, ind uint64
+	var i int
+
+	for j, c := range s {
+		if c > 255 {
+			return u, &InvalidCharError{Char: c, Offset: j}
+		}
+		ind = uint64(reverse{{.Name}}[c])
+		if ind == 255 {
+			return u, &InvalidCharError{Char: c, Offset: j}
+		}
+		n64 = n64*{{.Base}} + ind
+		i++
+		if i == {{.Short}}MaxU64Digits {
+			n, err = n.mulAdd64({{.Short}}MaxU64Divisor, n64)
+			if err != nil {
+				return
+			}
+			i = 0
+			n64 = 0
+		}
+	}
+	if i > 0 {
+		n, err = n.mulAdd64(pows{{.Name}}[i], n64)
+		if err != nil {
+			return
+		}
+	}
+	binary.BigEndian.PutUint64(u[:8], n.Hi)
+	binary.BigEndian.PutUint64(u[8:], n.Lo)
+	return
+}
+
+// DecodeStrict is like Decode but requires s to be exactly {{.EncLen}} characters long,
+// so that every UUID has exactly one valid encoding. It returns ErrLength if s
+// has the wrong length and ErrOverflow if s represents a number that does not
+// fit in 128 bits.
+func (e {{.Type}}) DecodeStrict(s string) (uuid.UUID, error) {
+	if len(s) != {{.EncLen}} {
+		return uuid.UUID{}, fmt.Errorf("%w: expected {{.EncLen}} characters, got %d", ErrLength, utf8.RuneCountInString(s))
+	}
+	return e.Decode(s)
+}
+
+// DecodeBytes is like Decode but takes a byte slice, which avoids converting
+// it to a string first.
+func (e {{.Type}}) DecodeBytes(b []byte) (uuid.UUID, error) {
+	return e.Decode(unsafe.String(unsafe.SliceData(b), len(b)))
+}
+
+// pows{{.Name}} holds the powers of {{.Base}} that fit in a uint64.
+var pows{{.Name}} = [{{len .Pows}}]uint64{ {{- range $i, $p := .Pows}}{{if $i}}, {{end}}{{$p}}{{end -}} }
+
+// reverse{{.Name}} is a lookup table for fast decoding, like reverseB57.
+var reverse{{.Name}} = [256]byte{
+{{table .Reverse -}}
+}
+`))
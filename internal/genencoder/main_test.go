@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// TestGenerated checks that the generated encoders in the root package are up
+// to date. Run go generate in the root package if it fails.
+func TestGenerated(t *testing.T) {
+	tests := []struct {
+		name, typ, alphabet, file string
+	}{
+		{"Base62", "b62Encoder", "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz", "b62_gen.go"},
+		{"Base58", "b58Encoder", "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz", "b58_gen.go"},
+		{"Base36", "b36Encoder", "0123456789abcdefghijklmnopqrstuvwxyz", "b36_gen.go"},
+		{"Base64URL", "b64URLEncoder", "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_", "b64url_gen.go"},
+	}
+	for _, test := range tests {
+		src, err := generate(test.name, test.typ, test.alphabet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := os.ReadFile(filepath.Join("..", "..", test.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(src, want) {
+			t.Errorf("%s is out of date, run go generate", test.file)
+		}
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	for _, abc := range []string{"a", "aaa", "abcう", "abc\n", "abc\x00", "abc\x7f"} {
+		if _, err := generate("Test", "testEncoder", abc); err == nil {
+			t.Errorf("expected an error for alphabet %q", abc)
+		}
+	}
+}
+
+func TestGenerateEscapes(t *testing.T) {
+	for _, abc := range []string{`abcn\`, `ab"c`, "ab c"} {
+		src, err := generate("Test", "testEncoder", abc)
+		if err != nil {
+			t.Fatalf("unexpected error for alphabet %q: %v", abc, err)
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+		if err != nil {
+			t.Fatalf("generated code for alphabet %q doesn't parse: %v", abc, err)
+		}
+		lit := f.Scope.Lookup("TestAlphabet").Decl.(*ast.ValueSpec).Values[0].(*ast.BasicLit)
+		got, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chars := []byte(abc)
+		slices.Sort(chars)
+		if got != string(chars) {
+			t.Errorf("expected TestAlphabet to be %q, got %q", chars, got)
+		}
+	}
+}
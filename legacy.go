@@ -0,0 +1,88 @@
+package shortuuid
+
+import (
+	"errors"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/google/uuid"
+)
+
+// LegacyPythonEncoder encodes and decodes UUIDs like versions of the Python
+// library shortuuid before 1.0, which put the least significant digit first
+// and padded the output at the end. It uses DefaultAlphabet. Use
+// NewLegacyPythonEncoder for other alphabets.
+//
+// Since the digits are reversed, the encoded IDs don't sort in the same order
+// as the UUIDs. Only use it to read and write existing IDs.
+var LegacyPythonEncoder Encoder = legacyEncoder{encoder{defaultAlphabet}}
+
+// legacyEncoder is an encoder with the digits in reverse order.
+type legacyEncoder struct {
+	enc encoder
+}
+
+// NewLegacyPythonEncoder is like NewEncoder, but returns an Encoder that is
+// compatible with versions of the Python library shortuuid before 1.0, like
+// LegacyPythonEncoder.
+func NewLegacyPythonEncoder(abc string) (Encoder, error) {
+	a, err := parseAlphabet(abc)
+	if err != nil {
+		return nil, err
+	}
+	return legacyEncoder{encoder{a}}, nil
+}
+
+func (e legacyEncoder) alpha() *alphabet {
+	return &e.enc.alphabet
+}
+
+func (e legacyEncoder) Encode(u uuid.UUID) string {
+	buf := reverseRunes(e.enc.AppendEncode(make([]byte, 0, int(e.enc.alphabet.encLen)*int(e.enc.alphabet.maxBytes)), u))
+	return unsafe.String(unsafe.SliceData(buf), len(buf))
+}
+
+// Decode decodes s into a uuid.UUID. If s is too short, its most significant
+// digits, i.e. the ones at the end, are taken to be 0, like the Python
+// library does. Use DecodeStrict to reject such inputs.
+func (e legacyEncoder) Decode(s string) (uuid.UUID, error) {
+	r := reverseRunes([]byte(s))
+	u, err := e.enc.Decode(unsafe.String(unsafe.SliceData(r), len(r)))
+	return u, fixReversedOffset(r, err)
+}
+
+// DecodeStrict implements StrictDecoder.
+func (e legacyEncoder) DecodeStrict(s string) (uuid.UUID, error) {
+	r := reverseRunes([]byte(s))
+	u, err := e.enc.DecodeStrict(unsafe.String(unsafe.SliceData(r), len(r)))
+	return u, fixReversedOffset(r, err)
+}
+
+// reverseRunes reverses the order of the UTF-8 encoded characters in b in
+// place and returns it. Invalid bytes are treated as single characters.
+func reverseRunes(b []byte) []byte {
+	for i := 0; i < len(b); {
+		_, size := utf8.DecodeRune(b[i:])
+		reverseBytes(b[i : i+size])
+		i += size
+	}
+	reverseBytes(b)
+	return b
+}
+
+func reverseBytes(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
+
+// fixReversedOffset translates the offset of an *InvalidCharError in r, which
+// was reversed by reverseRunes, back to the original input.
+func fixReversedOffset(r []byte, err error) error {
+	var charErr *InvalidCharError
+	if !errors.As(err, &charErr) {
+		return err
+	}
+	_, size := utf8.DecodeRune(r[charErr.Offset:])
+	return &InvalidCharError{Char: charErr.Char, Offset: len(r) - charErr.Offset - size}
+}
@@ -0,0 +1,107 @@
+package shortuuid
+
+import (
+	"errors"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Computed with a standalone copy of ShortUUID._num_to_string and
+// ShortUUID._string_to_int from shortuuid 0.5.0 for Python, since the package
+// couldn't be installed where they were generated. To check them against the
+// real package, pass the UUIDs below to:
+//
+//	pip install shortuuid==0.5.0
+//	python3 -c 'import sys, uuid, shortuuid; [print(u, shortuuid.ShortUUID().encode(uuid.UUID(u))) for u in sys.argv[1:]]'
+//
+// and the UUID in TestLegacyPythonEncoderCustomAlphabet to the same command
+// with ShortUUID("0123456789abcdef").
+var legacyTestVector = []struct {
+	uuid      string
+	shortuuid string
+}{
+	{"00000000-0000-0000-0000-000000000000", "2222222222222222222222"},
+	{"00000000-0000-0000-0000-000000000001", "3222222222222222222222"},
+	{"0e4b8ae1-8d8c-4bbc-a6e2-f3e2c7b8e5c3", "Y9XHDzNNfVfErRFxnXWyY4"},
+	{"64d1355f-d052-4bd9-83f4-39b93fb1c01f", "fCd2nkGhNF9UBcxpDsySwK"},
+	{"ffffffff-ffff-ffff-ffff-ffffffffffff", "5B8cwPMGnU6qLbRvo7qEZo"},
+}
+
+func TestLegacyPythonEncoder(t *testing.T) {
+	for _, test := range legacyTestVector {
+		u := uuid.MustParse(test.uuid)
+		if s := LegacyPythonEncoder.Encode(u); s != test.shortuuid {
+			t.Errorf("expected %q, got %q", test.shortuuid, s)
+		}
+		got, err := LegacyPythonEncoder.Decode(test.shortuuid)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if got != u {
+			t.Errorf("expected %q, got %q", u, got)
+		}
+	}
+
+	// Like ShortUUID.decode, missing digits at the end are 0.
+	u, err := LegacyPythonEncoder.Decode("3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := uuid.MustParse("00000000-0000-0000-0000-000000000001"); u != exp {
+		t.Errorf("expected %q, got %q", exp, u)
+	}
+	if _, err := LegacyPythonEncoder.(StrictDecoder).DecodeStrict("3"); !errors.Is(err, ErrLength) {
+		t.Errorf("expected %v, got %v", ErrLength, err)
+	}
+}
+
+func TestLegacyPythonEncoderCustomAlphabet(t *testing.T) {
+	enc, err := NewLegacyPythonEncoder("0123456789abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f")
+	exp := "f10c1bf39b934f389db4250df5531d46"
+	if s := enc.Encode(u); s != exp {
+		t.Errorf("expected %q, got %q", exp, s)
+	}
+
+	mb, _ := NewLegacyPythonEncoder("うえおなにぬねのウエオナニヌネノ")
+	if got, err := mb.Decode(mb.Encode(u)); err != nil || got != u {
+		t.Errorf("expected %q, got %q, %v", u, got, err)
+	}
+
+	if _, err := NewLegacyPythonEncoder("a"); !errors.Is(err, errAlphabetTooShort) {
+		t.Errorf("expected %v, got %v", errAlphabetTooShort, err)
+	}
+}
+
+func TestLegacyPythonEncoderInvalidChar(t *testing.T) {
+	mb, _ := NewLegacyPythonEncoder("うえおなにぬねのウエオナニヌネノ")
+	tests := []struct {
+		enc       Encoder
+		shortuuid string
+		char      rune
+		offset    int
+	}{
+		{LegacyPythonEncoder, "0Cd2nkGhNF9UBcxpDsySwK", '0', 0},
+		{LegacyPythonEncoder, "fCd2nkGhNF9UBcxpDsySw0", '0', 21},
+		{LegacyPythonEncoder, "fCd2nkGhNFうUBcxpDsySwK", 'う', 10},
+		{LegacyPythonEncoder, "fCd\xff", utf8.RuneError, 3},
+		{mb, "うえaお", 'a', 6},
+	}
+	for _, test := range tests {
+		_, err := test.enc.Decode(test.shortuuid)
+		var charErr *InvalidCharError
+		if !errors.As(err, &charErr) {
+			t.Errorf("expected *InvalidCharError for %q, got %v", test.shortuuid, err)
+			continue
+		}
+		if charErr.Char != test.char || charErr.Offset != test.offset {
+			t.Errorf("expected %q at offset %d, got %q at offset %d", test.char, test.offset, charErr.Char, charErr.Offset)
+		}
+	}
+}
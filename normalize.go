@@ -0,0 +1,129 @@
+package shortuuid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// DefaultAliases maps the look-alike characters that DefaultAlphabet excludes
+// to the characters they are most likely to be mistaken for.
+var DefaultAliases = map[rune]rune{
+	'0': 'o',
+	'O': 'o',
+	'1': 'i',
+	'I': 'i',
+	'l': 'i',
+}
+
+// Normalization describes how input is normalized before it is decoded.
+type Normalization struct {
+	// Aliases maps characters that are not part of the alphabet to
+	// characters that are.
+	Aliases map[rune]rune
+
+	// Skip lists characters that are ignored, such as "-".
+	Skip string
+
+	// SkipSpace ignores all white space, as defined by unicode.IsSpace.
+	SkipSpace bool
+}
+
+// normalizingEncoder wraps an Encoder and normalizes input before decoding.
+type normalizingEncoder struct {
+	enc Encoder
+	n   Normalization
+}
+
+// NewNormalizingEncoder returns an Encoder that encodes like enc, but
+// normalizes its input according to n before decoding it, so that it accepts
+// what users type instead of rejecting it. If enc is built on an alphabet,
+// like DefaultEncoder, Base62Encoder and the other generated encoders,
+// LegacyPythonEncoder, and the encoders returned by NewEncoder and
+// NewLegacyPythonEncoder, an error is returned if an alias would change the
+// meaning of a character in its alphabet, or if a character to be skipped is
+// part of it.
+//
+// The returned Encoder implements StrictDecoder by normalizing the input and
+// passing it to the DecodeStrict method of enc. If enc has none, DecodeStrict
+// returns ErrStrictUnsupported.
+func NewNormalizingEncoder(enc Encoder, n Normalization) (Encoder, error) {
+	aliases := make(map[rune]rune, len(n.Aliases))
+	for from, to := range n.Aliases {
+		if strings.ContainsRune(n.Skip, from) || (n.SkipSpace && unicode.IsSpace(from)) {
+			return nil, fmt.Errorf("character %q is both aliased and skipped", from)
+		}
+		aliases[from] = to
+	}
+	n.Aliases = aliases
+
+	if ae, ok := enc.(alphabetEncoder); ok {
+		abc := ae.alpha()
+		for from, to := range n.Aliases {
+			if _, err := abc.Index(from); err == nil {
+				return nil, fmt.Errorf("aliased character %q is part of the alphabet", from)
+			}
+			if _, err := abc.Index(to); err != nil {
+				return nil, fmt.Errorf("alias target %q is not part of the alphabet", to)
+			}
+		}
+		for _, c := range abc.chars {
+			if strings.ContainsRune(n.Skip, c) || (n.SkipSpace && unicode.IsSpace(c)) {
+				return nil, fmt.Errorf("skipped character %q is part of the alphabet", c)
+			}
+		}
+	}
+	return normalizingEncoder{enc, n}, nil
+}
+
+func (e normalizingEncoder) Encode(u uuid.UUID) string {
+	return e.enc.Encode(u)
+}
+
+func (e normalizingEncoder) Decode(s string) (uuid.UUID, error) {
+	u, err := e.enc.Decode(strings.Map(e.normalize, s))
+	return u, e.fixOffset(s, err)
+}
+
+// DecodeStrict implements StrictDecoder.
+func (e normalizingEncoder) DecodeStrict(s string) (uuid.UUID, error) {
+	u, err := decodeStrict(e.enc, strings.Map(e.normalize, s))
+	return u, e.fixOffset(s, err)
+}
+
+// normalize is the mapping function used with strings.Map. It returns -1 for
+// characters that should be skipped.
+func (e normalizingEncoder) normalize(r rune) rune {
+	if strings.ContainsRune(e.n.Skip, r) || (e.n.SkipSpace && unicode.IsSpace(r)) {
+		return -1
+	}
+	if to, ok := e.n.Aliases[r]; ok {
+		return to
+	}
+	return r
+}
+
+// fixOffset translates the offset of an *InvalidCharError from the normalized
+// form of s back to s itself.
+func (e normalizingEncoder) fixOffset(s string, err error) error {
+	var charErr *InvalidCharError
+	if !errors.As(err, &charErr) {
+		return err
+	}
+	pos := 0
+	for i, r := range s {
+		m := e.normalize(r)
+		if m < 0 {
+			continue
+		}
+		if pos == charErr.Offset {
+			return &InvalidCharError{Char: r, Offset: i}
+		}
+		pos += utf8.RuneLen(m)
+	}
+	return err
+}
@@ -0,0 +1,96 @@
+package shortuuid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNormalizingEncoder(t *testing.T) {
+	enc, err := NewNormalizingEncoder(DefaultEncoder, Normalization{
+		Aliases:   DefaultAliases,
+		Skip:      "-",
+		SkipSpace: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := uuid.MustParse("1e86f5b0-b479-4dd2-9d3c-0c0b9a63db30")
+	s := enc.Encode(u)
+	if s != "7Sbj56y9EQb8PPKGvNhpbW" {
+		t.Errorf("expected %q, got %q", "7Sbj56y9EQb8PPKGvNhpbW", s)
+	}
+
+	u = uuid.MustParse("2f56ebd5-373f-478f-afab-1142b74b75bc")
+	tests := []string{
+		"AS7NiY8gezxKjbcYM4d7iH",
+		"AS7N1Y8gezxKjbcYM4d7IH",
+		"AS7NlY8gezxKjbcYM4d7iH",
+		"AS7Ni-Y8gez-xKjbc-YM4d7-iH",
+		" AS7NiY8gez xKjbcYM4d7iH\n",
+	}
+	for _, s := range tests {
+		u2, err := enc.(StrictDecoder).DecodeStrict(s)
+		if err != nil {
+			t.Errorf("unexpected error decoding %q: %v", s, err)
+			continue
+		}
+		if u != u2 {
+			t.Errorf("expected %q, got %q", u, u2)
+		}
+	}
+
+	u2, err := enc.Decode("Po")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u3, _ := enc.Decode("P0")
+	if u2 != u3 {
+		t.Errorf("expected %q, got %q", u2, u3)
+	}
+}
+
+func TestNormalizingEncoderInvalidChar(t *testing.T) {
+	enc, err := NewNormalizingEncoder(DefaultEncoder, Normalization{Skip: "-", SkipSpace: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var charErr *InvalidCharError
+	_, err = enc.Decode("KwSys-DpxcB-U9FNh-Gkn2d-C0")
+	if !errors.As(err, &charErr) || charErr.Char != '0' || charErr.Offset != 25 {
+		t.Errorf("expected '0' at offset 25, got %v", err)
+	}
+	_, err = enc.Decode("う-KwSysDpxcB-U9FNh-Gkn2d-Cf")
+	if !errors.As(err, &charErr) || charErr.Char != 'う' || charErr.Offset != 0 {
+		t.Errorf("expected 'う' at offset 0, got %v", err)
+	}
+	_, err = enc.(StrictDecoder).DecodeStrict("KwSys-DpxcB-U9FNh-Gkn2d")
+	if !errors.Is(err, ErrLength) {
+		t.Errorf("expected %v, got %v", ErrLength, err)
+	}
+
+	lenient, _ := NewNormalizingEncoder(lenientEncoder{DefaultEncoder}, Normalization{Skip: "-"})
+	_, err = lenient.(StrictDecoder).DecodeStrict("KwSys-DpxcB-U9FNh-Gkn2d-Cf")
+	if !errors.Is(err, ErrStrictUnsupported) {
+		t.Errorf("expected %v, got %v", ErrStrictUnsupported, err)
+	}
+}
+
+func TestNewNormalizingEncoderErrors(t *testing.T) {
+	tests := []Normalization{
+		{Aliases: map[rune]rune{'a': 'b'}},
+		{Aliases: map[rune]rune{'0': 'l'}},
+		{Aliases: map[rune]rune{'-': 'a'}, Skip: "-"},
+		{Skip: "a"},
+	}
+	for _, n := range tests {
+		if _, err := NewNormalizingEncoder(DefaultEncoder, n); err == nil {
+			t.Errorf("expected an error for %+v", n)
+		}
+	}
+	abc, _ := NewEncoder("abc d")
+	if _, err := NewNormalizingEncoder(abc, Normalization{SkipSpace: true}); err == nil {
+		t.Error("expected an error for an alphabet with spaces")
+	}
+}
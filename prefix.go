@@ -0,0 +1,281 @@
+package shortuuid
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// PrefixSeparator separates the prefix from the encoded UUID in prefixed IDs,
+// e.g. usr_KwSysDpxcBU9FNhGkn2dCf.
+const PrefixSeparator = "_"
+
+// maxPrefixLen is the maximum length of a prefix, same as for TypeID.
+const maxPrefixLen = 63
+
+// validatePrefix reports whether prefix is 1 to 63 characters long, consists
+// of lowercase ASCII letters, digits and underscores, and starts and ends with
+// a letter.
+func validatePrefix(prefix string) error {
+	if prefix == "" || len(prefix) > maxPrefixLen {
+		return fmt.Errorf("%w: %q must be between 1 and %d characters", ErrPrefix, prefix, maxPrefixLen)
+	}
+	for i := 0; i < len(prefix); i++ {
+		c := prefix[i]
+		switch {
+		case 'a' <= c && c <= 'z':
+		case ('0' <= c && c <= '9') || c == '_':
+			if i == 0 || i == len(prefix)-1 {
+				return fmt.Errorf("%w: %q must start and end with a letter", ErrPrefix, prefix)
+			}
+		default:
+			return fmt.Errorf("%w: %q may only contain lowercase letters, digits and underscores", ErrPrefix, prefix)
+		}
+	}
+	return nil
+}
+
+// prefixEncoder wraps an Encoder and prepends a fixed prefix to its output.
+type prefixEncoder struct {
+	prefix string // including the separator
+	enc    Encoder
+}
+
+// NewPrefixEncoder returns an Encoder that prepends prefix and
+// PrefixSeparator to the output of enc, like usr_KwSysDpxcBU9FNhGkn2dCf.
+// Decode returns ErrPrefix if the input does not start with the same prefix.
+// The returned Encoder implements StrictDecoder by passing the rest of the
+// input to the DecodeStrict method of enc, and returns ErrStrictUnsupported if
+// enc has none.
+//
+// A prefix must be 1 to 63 characters long, consist of lowercase ASCII
+// letters, digits and underscores, and start and end with a letter.
+func NewPrefixEncoder(prefix string, enc Encoder) (Encoder, error) {
+	if err := validatePrefix(prefix); err != nil {
+		return nil, err
+	}
+	return prefixEncoder{prefix + PrefixSeparator, enc}, nil
+}
+
+func (e prefixEncoder) Encode(u uuid.UUID) string {
+	return e.prefix + e.enc.Encode(u)
+}
+
+func (e prefixEncoder) Decode(s string) (uuid.UUID, error) {
+	rest, err := e.cut(s)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	return e.enc.Decode(rest)
+}
+
+// DecodeStrict implements StrictDecoder.
+func (e prefixEncoder) DecodeStrict(s string) (uuid.UUID, error) {
+	rest, err := e.cut(s)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	return decodeStrict(e.enc, rest)
+}
+
+// cut removes the prefix from s, or returns ErrPrefix if s doesn't start with
+// it.
+func (e prefixEncoder) cut(s string) (string, error) {
+	rest, ok := strings.CutPrefix(s, e.prefix)
+	if !ok {
+		return "", fmt.Errorf("%w: expected %q in %q", ErrPrefix, strings.TrimSuffix(e.prefix, PrefixSeparator), s)
+	}
+	return rest, nil
+}
+
+// PrefixRegistry holds the set of prefixes that are allowed for prefixed IDs
+// and decodes IDs with any of them. It is safe for concurrent use.
+type PrefixRegistry struct {
+	enc Encoder
+
+	mu       sync.RWMutex
+	prefixes map[string]struct{}
+}
+
+// NewPrefixRegistry returns a PrefixRegistry that encodes UUIDs with enc and
+// allows the given prefixes.
+func NewPrefixRegistry(enc Encoder, prefixes ...string) (*PrefixRegistry, error) {
+	r := &PrefixRegistry{enc: enc, prefixes: make(map[string]struct{}, len(prefixes))}
+	for _, prefix := range prefixes {
+		if err := r.Register(prefix); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds prefix to the set of allowed prefixes.
+func (r *PrefixRegistry) Register(prefix string) error {
+	if err := validatePrefix(prefix); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prefixes[prefix] = struct{}{}
+	return nil
+}
+
+// Encoder returns an Encoder for the registered prefix, as returned by
+// NewPrefixEncoder.
+func (r *PrefixRegistry) Encoder(prefix string) (Encoder, error) {
+	if !r.registered(prefix) {
+		return nil, fmt.Errorf("%w: %q is not registered", ErrPrefix, prefix)
+	}
+	return prefixEncoder{prefix + PrefixSeparator, r.enc}, nil
+}
+
+// Encode encodes u with the registered prefix.
+func (r *PrefixRegistry) Encode(prefix string, u uuid.UUID) (string, error) {
+	enc, err := r.Encoder(prefix)
+	if err != nil {
+		return "", err
+	}
+	return enc.Encode(u), nil
+}
+
+// Decode splits s into its prefix and encoded UUID, and decodes the latter
+// with the Decode method of the registry's encoder, which may accept more than
+// the canonical form. It returns ErrPrefix if s does not start with a
+// registered prefix. If more than one registered prefix matches, the longest
+// one is used.
+func (r *PrefixRegistry) Decode(s string) (prefix string, u uuid.UUID, err error) {
+	prefix, rest, err := r.cut(s)
+	if err != nil {
+		return "", uuid.UUID{}, err
+	}
+	u, err = r.enc.Decode(rest)
+	return prefix, u, err
+}
+
+// DecodeStrict is like Decode, but decodes the encoded UUID with the
+// DecodeStrict method of the registry's encoder, so that only the canonical
+// form is accepted. It returns ErrStrictUnsupported if the encoder doesn't
+// implement StrictDecoder. Use it to decode IDs supplied by users.
+func (r *PrefixRegistry) DecodeStrict(s string) (prefix string, u uuid.UUID, err error) {
+	prefix, rest, err := r.cut(s)
+	if err != nil {
+		return "", uuid.UUID{}, err
+	}
+	u, err = decodeStrict(r.enc, rest)
+	return prefix, u, err
+}
+
+// cut splits s into the longest registered prefix and the rest after
+// PrefixSeparator.
+func (r *PrefixRegistry) cut(s string) (prefix, rest string, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for i := len(s) - 1; i > 0; i-- {
+		if !strings.HasPrefix(s[i:], PrefixSeparator) {
+			continue
+		}
+		if _, ok := r.prefixes[s[:i]]; ok {
+			return s[:i], s[i+len(PrefixSeparator):], nil
+		}
+	}
+	return "", "", fmt.Errorf("%w: no registered prefix in %q", ErrPrefix, s)
+}
+
+func (r *PrefixRegistry) registered(prefix string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.prefixes[prefix]
+	return ok
+}
+
+// Prefixer is implemented by the types that tag a TypedID with a prefix.
+// Prefix must return a valid prefix (see NewPrefixEncoder), and should not
+// depend on the value of the receiver. For example:
+//
+//	type User struct{}
+//
+//	func (User) Prefix() string { return "usr" }
+//
+//	type UserID = shortuuid.TypedID[User]
+type Prefixer interface {
+	Prefix() string
+}
+
+// TypedID is a UUID that is tagged with the prefix of T, so that IDs of
+// different entity types cannot be mixed up. It is represented as the
+// prefix, PrefixSeparator and the base57 encoded UUID when formatted or
+// marshaled as text or JSON, like usr_KwSysDpxcBU9FNhGkn2dCf.
+type TypedID[T Prefixer] uuid.UUID
+
+// ParseTypedID decodes s into a TypedID, and returns ErrPrefix if s does not
+// start with the prefix of T. Since IDs are often supplied by users, only the
+// canonical form is accepted: ErrLength is returned if the encoded UUID is not
+// exactly 22 characters long.
+func ParseTypedID[T Prefixer](s string) (TypedID[T], error) {
+	var id TypedID[T]
+	err := id.UnmarshalText([]byte(s))
+	return id, err
+}
+
+// Prefix returns the prefix of T.
+func (id TypedID[T]) Prefix() string {
+	var t T
+	return t.Prefix()
+}
+
+// UUID returns id as a uuid.UUID.
+func (id TypedID[T]) UUID() uuid.UUID {
+	return uuid.UUID(id)
+}
+
+// String returns the prefixed base57 representation of id.
+func (id TypedID[T]) String() string {
+	return id.Prefix() + PrefixSeparator + DefaultEncoder.Encode(uuid.UUID(id))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id TypedID[T]) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Like ParseTypedID, it
+// only accepts the canonical form.
+func (id *TypedID[T]) UnmarshalText(data []byte) error {
+	enc := prefixEncoder{id.Prefix() + PrefixSeparator, DefaultEncoder}
+	u, err := enc.DecodeStrict(string(data))
+	if err != nil {
+		return err
+	}
+	*id = TypedID[T](u)
+	return nil
+}
+
+// Scan implements sql.Scanner. In addition to the prefixed form, it accepts
+// everything that ID.Scan does. Like there, a 16-byte []byte is always read as
+// the raw bytes of the UUID, even if it happens to start with the prefix.
+func (id *TypedID[T]) Scan(src any) error {
+	var s string
+	switch src := src.(type) {
+	case string:
+		s = src
+	case []byte:
+		if len(src) == len(id) {
+			copy(id[:], src)
+			return nil
+		}
+		s = string(src)
+	}
+	if strings.HasPrefix(s, id.Prefix()+PrefixSeparator) {
+		return id.UnmarshalText([]byte(s))
+	}
+	return (*ID)(id).Scan(src)
+}
+
+// Value implements driver.Valuer. Like ID.Value, it writes id as a canonical
+// UUID string.
+func (id TypedID[T]) Value() (driver.Value, error) {
+	return ID(id).Value()
+}
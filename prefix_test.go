@@ -0,0 +1,230 @@
+package shortuuid
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type testUser struct{}
+
+func (testUser) Prefix() string { return "usr" }
+
+type testOrder struct{}
+
+func (testOrder) Prefix() string { return "ord" }
+
+func TestValidatePrefix(t *testing.T) {
+	valid := []string{"usr", "a", "user_account", "v2a"}
+	for _, prefix := range valid {
+		if err := validatePrefix(prefix); err != nil {
+			t.Errorf("expected %q to be valid, got %v", prefix, err)
+		}
+	}
+	invalid := []string{"", "Usr", "_usr", "usr_", "2usr", "us-r", "うえ", string(make([]byte, 64))}
+	for _, prefix := range invalid {
+		if err := validatePrefix(prefix); !errors.Is(err, ErrPrefix) {
+			t.Errorf("expected %q to be invalid, got %v", prefix, err)
+		}
+	}
+}
+
+func TestPrefixEncoder(t *testing.T) {
+	enc, err := NewPrefixEncoder("usr", DefaultEncoder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f")
+	s := enc.Encode(u)
+	if s != "usr_KwSysDpxcBU9FNhGkn2dCf" {
+		t.Errorf("expected %q, got %q", "usr_KwSysDpxcBU9FNhGkn2dCf", s)
+	}
+	u2, err := enc.Decode(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u != u2 {
+		t.Errorf("expected %q, got %q", u, u2)
+	}
+	for _, s := range []string{"KwSysDpxcBU9FNhGkn2dCf", "ord_KwSysDpxcBU9FNhGkn2dCf", "usrKwSysDpxcBU9FNhGkn2dCf"} {
+		if _, err := enc.Decode(s); !errors.Is(err, ErrPrefix) {
+			t.Errorf("expected %v for %q, got %v", ErrPrefix, s, err)
+		}
+	}
+	if _, err := enc.(StrictDecoder).DecodeStrict("usr_3"); !errors.Is(err, ErrLength) {
+		t.Errorf("expected %v, got %v", ErrLength, err)
+	}
+	lenient, _ := NewPrefixEncoder("usr", lenientEncoder{DefaultEncoder})
+	if _, err := lenient.(StrictDecoder).DecodeStrict(s); !errors.Is(err, ErrStrictUnsupported) {
+		t.Errorf("expected %v, got %v", ErrStrictUnsupported, err)
+	}
+	if _, err := NewPrefixEncoder("Usr", DefaultEncoder); !errors.Is(err, ErrPrefix) {
+		t.Errorf("expected %v, got %v", ErrPrefix, err)
+	}
+}
+
+func TestPrefixRegistry(t *testing.T) {
+	r, err := NewPrefixRegistry(DefaultEncoder, "usr", "ord", "usr_admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f")
+
+	tests := []struct {
+		prefix string
+		s      string
+	}{
+		{"usr", "usr_KwSysDpxcBU9FNhGkn2dCf"},
+		{"ord", "ord_KwSysDpxcBU9FNhGkn2dCf"},
+		{"usr_admin", "usr_admin_KwSysDpxcBU9FNhGkn2dCf"},
+	}
+	for _, test := range tests {
+		s, err := r.Encode(test.prefix, u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != test.s {
+			t.Errorf("expected %q, got %q", test.s, s)
+		}
+		prefix, u2, err := r.Decode(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if prefix != test.prefix || u2 != u {
+			t.Errorf("expected %q and %q, got %q and %q", test.prefix, u, prefix, u2)
+		}
+	}
+
+	if _, err := r.Encode("inv", u); !errors.Is(err, ErrPrefix) {
+		t.Errorf("expected %v, got %v", ErrPrefix, err)
+	}
+	if _, _, err := r.Decode("inv_KwSysDpxcBU9FNhGkn2dCf"); !errors.Is(err, ErrPrefix) {
+		t.Errorf("expected %v, got %v", ErrPrefix, err)
+	}
+	if err := r.Register("inv"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := r.Decode("inv_KwSysDpxcBU9FNhGkn2dCf"); err != nil {
+		t.Error(err)
+	}
+	if _, err := NewPrefixRegistry(DefaultEncoder, "_"); !errors.Is(err, ErrPrefix) {
+		t.Errorf("expected %v, got %v", ErrPrefix, err)
+	}
+}
+
+func TestPrefixRegistryDecodeStrict(t *testing.T) {
+	r, err := NewPrefixRegistry(DefaultEncoder, "usr", "usr_admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f")
+
+	prefix, u2, err := r.DecodeStrict("usr_admin_KwSysDpxcBU9FNhGkn2dCf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prefix != "usr_admin" || u2 != u {
+		t.Errorf("expected %q and %q, got %q and %q", "usr_admin", u, prefix, u2)
+	}
+
+	// Decode accepts short encodings, DecodeStrict doesn't.
+	if _, _, err := r.Decode("usr_2"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if _, _, err := r.DecodeStrict("usr_2"); !errors.Is(err, ErrLength) {
+		t.Errorf("expected %v, got %v", ErrLength, err)
+	}
+	if _, _, err := r.DecodeStrict("inv_KwSysDpxcBU9FNhGkn2dCf"); !errors.Is(err, ErrPrefix) {
+		t.Errorf("expected %v, got %v", ErrPrefix, err)
+	}
+
+	r, err = NewPrefixRegistry(lenientEncoder{DefaultEncoder}, "usr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := r.DecodeStrict("usr_KwSysDpxcBU9FNhGkn2dCf"); !errors.Is(err, ErrStrictUnsupported) {
+		t.Errorf("expected %v, got %v", ErrStrictUnsupported, err)
+	}
+}
+
+func TestTypedID(t *testing.T) {
+	u := uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f")
+	id := TypedID[testUser](u)
+	if id.String() != "usr_KwSysDpxcBU9FNhGkn2dCf" {
+		t.Errorf("expected %q, got %q", "usr_KwSysDpxcBU9FNhGkn2dCf", id.String())
+	}
+
+	id2, err := ParseTypedID[testUser]("usr_KwSysDpxcBU9FNhGkn2dCf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id2 != id {
+		t.Errorf("expected %q, got %q", id, id2)
+	}
+	if _, err := ParseTypedID[testOrder]("usr_KwSysDpxcBU9FNhGkn2dCf"); !errors.Is(err, ErrPrefix) {
+		t.Errorf("expected %v, got %v", ErrPrefix, err)
+	}
+	for _, s := range []string{"usr_3", "usr_", "usr_2KwSysDpxcBU9FNhGkn2dCf"} {
+		if _, err := ParseTypedID[testUser](s); !errors.Is(err, ErrLength) {
+			t.Errorf("expected %v for %q, got %v", ErrLength, s, err)
+		}
+	}
+}
+
+func TestTypedIDJSON(t *testing.T) {
+	type payload struct {
+		User  TypedID[testUser]  `json:"user"`
+		Order TypedID[testOrder] `json:"order"`
+	}
+	u := uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f")
+	b, err := json.Marshal(payload{TypedID[testUser](u), TypedID[testOrder](u)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `{"user":"usr_KwSysDpxcBU9FNhGkn2dCf","order":"ord_KwSysDpxcBU9FNhGkn2dCf"}`
+	if string(b) != exp {
+		t.Errorf("expected %s, got %s", exp, b)
+	}
+	var p payload
+	if err := json.Unmarshal(b, &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.User.UUID() != u || p.Order.UUID() != u {
+		t.Errorf("expected %q, got %q and %q", u, p.User, p.Order)
+	}
+	if err := json.Unmarshal([]byte(`{"user":"ord_KwSysDpxcBU9FNhGkn2dCf"}`), &p); !errors.Is(err, ErrPrefix) {
+		t.Errorf("expected %v, got %v", ErrPrefix, err)
+	}
+}
+
+func TestTypedIDSQL(t *testing.T) {
+	u := uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f")
+	for _, src := range []any{"usr_KwSysDpxcBU9FNhGkn2dCf", []byte("usr_KwSysDpxcBU9FNhGkn2dCf"), u.String(), u[:]} {
+		var id TypedID[testUser]
+		if err := id.Scan(src); err != nil {
+			t.Errorf("unexpected error scanning %v: %v", src, err)
+		}
+		if id.UUID() != u {
+			t.Errorf("expected %q, got %q", u, id.UUID())
+		}
+	}
+	// Raw bytes that look like the prefix are still raw bytes.
+	raw := uuid.UUID{'u', 's', 'r', '_', 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	var id TypedID[testUser]
+	if err := id.Scan(raw[:]); err != nil {
+		t.Errorf("unexpected error scanning %v: %v", raw[:], err)
+	}
+	if id.UUID() != raw {
+		t.Errorf("expected %q, got %q", raw, id.UUID())
+	}
+
+	v, err := TypedID[testUser](u).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != u.String() {
+		t.Errorf("expected %q, got %q", u.String(), v)
+	}
+}
@@ -9,6 +9,8 @@ package shortuuid
 
 import (
 	"crypto/sha1"
+	"fmt"
+	"io"
 	"strings"
 	"unsafe"
 
@@ -19,38 +21,115 @@ import (
 // based on Base57.
 var DefaultEncoder = b57Encoder{}
 
+// AppendEncoder is implemented by encoders that can encode into and decode
+// from caller-provided byte slices without allocating. DefaultEncoder, the
+// other built-in encoders such as Base62Encoder, and the encoders returned by
+// NewEncoder implement it.
+type AppendEncoder interface {
+	Encoder
+	AppendEncode(dst []byte, u uuid.UUID) []byte
+	EncodeTo(dst []byte, u uuid.UUID) (int, error)
+	DecodeBytes(b []byte) (uuid.UUID, error)
+}
+
+// StrictDecoder is implemented by encoders that can reject inputs that are
+// not in canonical form, such as inputs that are too short or that have extra
+// leading zero digits. DefaultEncoder, the other built-in encoders such as
+// Base62Encoder, and the encoders returned by NewEncoder implement it.
+type StrictDecoder interface {
+	DecodeStrict(string) (uuid.UUID, error)
+}
+
+// decodeStrict decodes s with the DecodeStrict method of enc. It never falls
+// back to Decode, since that would accept non-canonical input, but returns
+// ErrStrictUnsupported if enc doesn't implement StrictDecoder.
+func decodeStrict(enc Encoder, s string) (uuid.UUID, error) {
+	dec, ok := enc.(StrictDecoder)
+	if !ok {
+		return uuid.UUID{}, fmt.Errorf("%w by %T", ErrStrictUnsupported, enc)
+	}
+	return dec.DecodeStrict(s)
+}
+
+// defaultAlphabet is the parsed form of DefaultAlphabet.
+var defaultAlphabet = newAlphabet(DefaultAlphabet)
+
 // Encoder is an interface for encoding/decoding UUIDs to strings.
 type Encoder interface {
 	Encode(uuid.UUID) string
 	Decode(string) (uuid.UUID, error)
 }
 
+// NewEncoder returns an Encoder that encodes and decodes UUIDs using the
+// alphabet abc. The alphabet will be automatically sorted and deduplicated to
+// ensure consistency.
+//
+// The returned Encoder is immutable and safe for concurrent use. An error is
+// returned if abc (after removing duplicates) has fewer than 2 characters.
+func NewEncoder(abc string) (Encoder, error) {
+	a, err := parseAlphabet(abc)
+	if err != nil {
+		return nil, err
+	}
+	return encoder{a}, nil
+}
+
 // New returns a new UUIDv4, encoded with base57.
 func New() string {
-	return DefaultEncoder.Encode(uuid.New())
+	return defaultGenerator.New()
 }
 
 // NewWithEncoder returns a new UUIDv4, encoded with enc.
 func NewWithEncoder(enc Encoder) string {
-	return enc.Encode(uuid.New())
+	return enc.Encode(defaultGenerator.mustNewUUID())
+}
+
+// NewV7 returns a new time-ordered UUIDv7, encoded with base57.
+//
+// Since the encoded form has a fixed length and the most significant digit
+// comes first, IDs generated by NewV7 sort lexicographically in the same order
+// as the underlying UUIDs. Panics if the UUID cannot be generated.
+func NewV7() string {
+	return defaultV7Generator.New()
+}
+
+// NewV7WithEncoder returns a new time-ordered UUIDv7, encoded with enc.
+//
+// Ordering is only preserved if enc produces fixed-length output with the most
+// significant digit first, such as the encoders returned by NewEncoder.
+// Panics if the UUID cannot be generated.
+func NewV7WithEncoder(enc Encoder) string {
+	return enc.Encode(defaultV7Generator.mustNewUUID())
 }
 
 // NewWithNamespace returns a new UUIDv5 (or v4 if name is empty), encoded with base57.
 func NewWithNamespace(name string) string {
-	var u uuid.UUID
-
-	switch {
-	case name == "":
-		u = uuid.New()
-	case hasPrefixCaseInsensitive(name, "https://"):
-		u = hashedUUID(uuid.NameSpaceURL, name)
-	case hasPrefixCaseInsensitive(name, "http://"):
-		u = hashedUUID(uuid.NameSpaceURL, name)
-	default:
-		u = hashedUUID(uuid.NameSpaceDNS, name)
-	}
+	return defaultGenerator.NewWithNamespace(name)
+}
+
+// NewV5 returns the UUIDv5 for name in the namespace, encoded with base57.
+// The namespace can be any UUID, such as uuid.NameSpaceOID, uuid.NameSpaceX500
+// or one of your own. Unlike NewWithNamespace, the namespace is never guessed
+// from name.
+func NewV5(namespace uuid.UUID, name []byte) string {
+	return NewV5WithEncoder(DefaultEncoder, namespace, name)
+}
+
+// NewV5WithEncoder is like NewV5 but encodes the UUID with enc.
+func NewV5WithEncoder(enc Encoder, namespace uuid.UUID, name []byte) string {
+	return enc.Encode(uuid.NewSHA1(namespace, name))
+}
 
-	return DefaultEncoder.Encode(u)
+// NewV3 returns the UUIDv3 for name in the namespace, encoded with base57.
+// UUIDv3 uses MD5 instead of SHA-1, and should only be used for compatibility
+// with existing IDs. Prefer NewV5 otherwise.
+func NewV3(namespace uuid.UUID, name []byte) string {
+	return NewV3WithEncoder(DefaultEncoder, namespace, name)
+}
+
+// NewV3WithEncoder is like NewV3 but encodes the UUID with enc.
+func NewV3WithEncoder(enc Encoder, namespace uuid.UUID, name []byte) string {
+	return enc.Encode(uuid.NewMD5(namespace, name))
 }
 
 // NewWithAlphabet returns a new UUIDv4, encoded using the alternative
@@ -61,7 +140,39 @@ func NewWithNamespace(name string) string {
 // consistency.
 func NewWithAlphabet(abc string) string {
 	enc := encoder{newAlphabet(abc)}
-	return enc.Encode(uuid.New())
+	return enc.Encode(defaultGenerator.mustNewUUID())
+}
+
+// Random returns a cryptographically secure random string of length characters
+// drawn uniformly from the default base57 alphabet. Unlike New, the result is
+// not derived from a UUID and cannot be decoded. It is the equivalent of
+// ShortUUID().random(length) in the Python library.
+//
+// Panics if length is negative or if the system's secure random number
+// generator fails.
+func Random(length int) string {
+	return defaultGenerator.Random(length)
+}
+
+// RandomWithAlphabet is like Random but draws characters from the alternative
+// alphabet abc.
+//
+// Panics if abc (after removing duplicates) has fewer than 2 characters, if
+// length is negative, or if the system's secure random number generator fails.
+func RandomWithAlphabet(abc string, length int) string {
+	a := newAlphabet(abc)
+	return randomWithAlphabet(defaultGenerator.randReader(), &a, length)
+}
+
+func randomWithAlphabet(r io.Reader, a *alphabet, length int) string {
+	if length < 0 {
+		panic(fmt.Errorf("shortuuid: negative length %d", length))
+	}
+	s, err := a.randomString(r, length)
+	if err != nil {
+		panic(err)
+	}
+	return s
 }
 
 func hasPrefixCaseInsensitive(s, prefix string) bool {
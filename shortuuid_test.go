@@ -1,8 +1,12 @@
 package shortuuid
 
 import (
+	"errors"
+	"io"
+	"slices"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -156,6 +160,132 @@ func TestNewWithNamespace(t *testing.T) {
 	}
 }
 
+func TestNewV5(t *testing.T) {
+	tests := []struct {
+		namespace uuid.UUID
+		name      string
+		v5        string
+		v3        string
+	}{
+		{uuid.NameSpaceOID, "1.3.6.1", "5cgrEaWf2hbD6Q8yZtewBY", "hMKVytXsmUwj8QUzD7G4RE"},
+		{uuid.NameSpaceX500, "cn=John Doe", "M5mFWy5Ay6rjsVkYLhTw7R", "TUDU7w64iyesd9QsyooTBV"},
+		{uuid.NameSpaceDNS, "example.com", "exu3DTbj2ncsn9tLdLWspw", "ThxSm3dFw8r2gedEpegmd9"},
+		{uuid.MustParse("0026636a-e9b3-4a88-9c66-bf49d8cad81f"), "user/42", "P7phjrAvo4LRmDszyxUuPV", "WK8u4DWtguJRgJorSAAr4C"},
+	}
+	hex := encoder{newAlphabet("0123456789abcdef")}
+	for _, test := range tests {
+		if s := NewV5(test.namespace, []byte(test.name)); s != test.v5 {
+			t.Errorf("expected %q, got %q", test.v5, s)
+		}
+		if s := NewV3(test.namespace, []byte(test.name)); s != test.v3 {
+			t.Errorf("expected %q, got %q", test.v3, s)
+		}
+
+		u, _ := DefaultEncoder.Decode(test.v5)
+		if s := NewV5WithEncoder(hex, test.namespace, []byte(test.name)); s != hex.Encode(u) {
+			t.Errorf("expected %q, got %q", hex.Encode(u), s)
+		}
+		u, _ = DefaultEncoder.Decode(test.v3)
+		if s := NewV3WithEncoder(hex, test.namespace, []byte(test.name)); s != hex.Encode(u) {
+			t.Errorf("expected %q, got %q", hex.Encode(u), s)
+		}
+	}
+
+	if s := NewV5(uuid.NameSpaceURL, []byte("http://www.example.com/")); s != NewWithNamespace("http://www.example.com/") {
+		t.Errorf("expected NewV5 to agree with NewWithNamespace, got %q", s)
+	}
+}
+
+func TestNewV7(t *testing.T) {
+	ids := make([]string, 1000)
+	for i := range ids {
+		ids[i] = NewV7()
+	}
+	if !slices.IsSorted(ids) {
+		t.Errorf("expected IDs generated by NewV7 to be sorted")
+	}
+	u, err := DefaultEncoder.Decode(ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Version() != 7 {
+		t.Errorf("expected UUID version 7, got %d", u.Version())
+	}
+}
+
+func TestNewV7WithEncoder(t *testing.T) {
+	enc, err := NewEncoder("0123456789abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, 1000)
+	for i := range ids {
+		ids[i] = NewV7WithEncoder(enc)
+	}
+	if !slices.IsSorted(ids) {
+		t.Errorf("expected IDs generated by NewV7WithEncoder to be sorted")
+	}
+}
+
+func TestEncodingPreservesOrder(t *testing.T) {
+	encoders := []Encoder{
+		DefaultEncoder,
+		encoder{newAlphabet(DefaultAlphabet)},
+		encoder{newAlphabet("0123456789abcdef")},
+		encoder{newAlphabet("うえおなにぬねのウエオナニヌネノ")},
+	}
+	for _, enc := range encoders {
+		for i := 1; i < len(testVector); i++ {
+			a := enc.Encode(uuid.MustParse(testVector[i-1].uuid))
+			b := enc.Encode(uuid.MustParse(testVector[i].uuid))
+			if a >= b {
+				t.Errorf("expected %q < %q (%T)", a, b, enc)
+			}
+		}
+	}
+}
+
+func TestRandom(t *testing.T) {
+	for _, length := range []int{0, 1, 8, 22, 100} {
+		s := Random(length)
+		if len(s) != length {
+			t.Errorf("expected length %d, got %d", length, len(s))
+		}
+		for _, c := range s {
+			if _, err := defaultAlphabet.Index(c); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+	if Random(22) == Random(22) {
+		t.Error("Random should generate different strings")
+	}
+}
+
+func TestRandomNegativeLength(t *testing.T) {
+	defer func() {
+		r := recover()
+		if err, ok := r.(error); !ok || err.Error() != "shortuuid: negative length -1" {
+			t.Errorf("expected a panic with %q, got %v", "shortuuid: negative length -1", r)
+		}
+	}()
+	Random(-1)
+}
+
+func TestRandomWithAlphabet(t *testing.T) {
+	abc := "うえおなにぬねのウエオナニヌネノ"
+	a := newAlphabet(abc)
+	s := RandomWithAlphabet(abc, 10)
+	if n := utf8.RuneCountInString(s); n != 10 {
+		t.Errorf("expected length 10, got %d", n)
+	}
+	for _, c := range s {
+		if _, err := a.Index(c); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
 func TestEncoding(t *testing.T) {
 	for _, test := range testVector {
 		u := uuid.MustParse(test.uuid)
@@ -204,6 +334,263 @@ func TestDecodingErrors(t *testing.T) {
 	}
 }
 
+func TestAppendEncode(t *testing.T) {
+	encoders := []AppendEncoder{
+		DefaultEncoder,
+		Base32CrockfordEncoder,
+		encoder{newAlphabet(DefaultAlphabet)},
+		encoder{newAlphabet("0123456789abcdef")},
+		encoder{newAlphabet("うえおなにぬねのウエオナニヌネノ")},
+	}
+	for _, enc := range encoders {
+		for _, test := range testVector[:10] {
+			u := uuid.MustParse(test.uuid)
+			exp := enc.Encode(u)
+
+			b := enc.AppendEncode([]byte("id="), u)
+			if string(b) != "id="+exp {
+				t.Errorf("expected %q, got %q", "id="+exp, b)
+			}
+
+			buf := make([]byte, len(exp)+1)
+			n, err := enc.EncodeTo(buf, u)
+			if err != nil {
+				t.Error(err)
+			}
+			if string(buf[:n]) != exp {
+				t.Errorf("expected %q, got %q", exp, buf[:n])
+			}
+			if _, err := enc.EncodeTo(buf[:len(exp)-1], u); err != io.ErrShortBuffer {
+				t.Errorf("expected io.ErrShortBuffer, got %v", err)
+			}
+
+			u2, err := enc.DecodeBytes([]byte(exp))
+			if err != nil {
+				t.Error(err)
+			}
+			if u != u2 {
+				t.Errorf("expected %q, got %q", u, u2)
+			}
+		}
+	}
+}
+
+func TestAppendEncodeAllocs(t *testing.T) {
+	u := uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f")
+	s := []byte("KwSysDpxcBU9FNhGkn2dCf")
+	buf := make([]byte, 0, 128)
+	encoders := []AppendEncoder{
+		DefaultEncoder,
+		encoder{newAlphabet("0123456789abcdef")},
+		encoder{newAlphabet("うえおなにぬねのウエオナニヌネノ")},
+	}
+	for _, enc := range encoders {
+		allocs := testing.AllocsPerRun(100, func() {
+			buf = enc.AppendEncode(buf[:0], u)
+			_, _ = enc.EncodeTo(buf[:cap(buf)], u)
+		})
+		if allocs != 0 {
+			t.Errorf("expected no allocations, got %v (%T)", allocs, enc)
+		}
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = DefaultEncoder.DecodeBytes(s)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestDecodeStrict(t *testing.T) {
+	for _, test := range testVector {
+		u, err := DefaultEncoder.DecodeStrict(test.shortuuid)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if u != uuid.MustParse(test.uuid) {
+			t.Errorf("expected %q, got %q", test.uuid, u)
+		}
+	}
+
+	tests := []struct {
+		shortuuid string
+		err       error
+	}{
+		{"", ErrLength},
+		{"2", ErrLength},
+		{"KwSysDpxcBU9FNhGkn2dC", ErrLength},
+		{"2KwSysDpxcBU9FNhGkn2dCf", ErrLength},
+		{"うwSysDpxcBU9FNhGkn2dC", ErrLength},
+		{"yoANdrf88xUXvwbS5GRbMN", ErrOverflow},
+		{"zzzzzzzzzzzzzzzzzzzzzz", ErrOverflow},
+	}
+	for _, test := range tests {
+		_, err := DefaultEncoder.DecodeStrict(test.shortuuid)
+		if !errors.Is(err, test.err) {
+			t.Errorf("expected %v for %q, got %v", test.err, test.shortuuid, err)
+		}
+	}
+}
+
+func TestDecodingAnyLength(t *testing.T) {
+	generic := encoder{newAlphabet(DefaultAlphabet)}
+	for n := 1; n <= 23; n++ {
+		for _, s := range []string{
+			strings.Repeat("2", n-1) + "3",
+			"3" + strings.Repeat("2", n-1),
+			"KwSysDpxcBU9FNhGkn2dCfz"[:n],
+			strings.Repeat("z", n),
+		} {
+			u, err := DefaultEncoder.Decode(s)
+			exp, expErr := generic.Decode(s)
+			if u != exp || !errors.Is(err, expErr) {
+				t.Errorf("expected %v, %v for %q, got %v, %v", exp, expErr, s, u, err)
+			}
+		}
+	}
+
+	_, err := DefaultEncoder.Decode("32222222222222222222223")
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("expected %v, got %v", ErrOverflow, err)
+	}
+}
+
+func TestDecodeStrictCustomAlphabet(t *testing.T) {
+	enc := encoder{newAlphabet("うえおなにぬねのウエオナニヌネノ")}
+	s := "えなネノなえオオエなにナにノなのエなナなねネなネノなうえにウネお"
+	u, err := enc.DecodeStrict(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := uuid.MustParse("13ef31aa-934b-4f37-93b3-6e3ef30148e2"); u != exp {
+		t.Errorf("expected %q, got %q", exp, u)
+	}
+	for _, s := range []string{"え", s[len("え"):], "う" + s} {
+		if _, err := enc.DecodeStrict(s); !errors.Is(err, ErrLength) {
+			t.Errorf("expected %v for %q, got %v", ErrLength, s, err)
+		}
+	}
+}
+
+func TestDecodingInvalidChar(t *testing.T) {
+	tests := []struct {
+		enc       Encoder
+		shortuuid string
+		char      rune
+		offset    int
+	}{
+		{DefaultEncoder, "1lIO022222222222222222", '1', 0},
+		{DefaultEncoder, "KwSysDpxcBU9FNhGkn2dC0", '0', 21},
+		{DefaultEncoder, "KwSysDpxcBうU9FNhGkn2d", 'う', 10},
+		{DefaultEncoder, "KwSysDpxcBÿU9FNhGkn2d", 'ÿ', 10},
+		{encoder{newAlphabet(DefaultAlphabet)}, "KwSysDpxcB-U9FNhGkn2dC", '-', 10},
+		{encoder{newAlphabet("うえおなにぬねのウエオナニヌネノ")}, "うえaお", 'a', 6},
+	}
+	for _, test := range tests {
+		_, err := test.enc.Decode(test.shortuuid)
+		var charErr *InvalidCharError
+		if !errors.As(err, &charErr) {
+			t.Errorf("expected *InvalidCharError for %q, got %v", test.shortuuid, err)
+			continue
+		}
+		if charErr.Char != test.char || charErr.Offset != test.offset {
+			t.Errorf("expected %q at offset %d, got %q at offset %d", test.char, test.offset, charErr.Char, charErr.Offset)
+		}
+		if !errors.Is(err, ErrInvalidChar) {
+			t.Errorf("expected %v to match ErrInvalidChar", err)
+		}
+	}
+}
+
+func TestDecodingOverflow(t *testing.T) {
+	encoders := []Encoder{DefaultEncoder, encoder{newAlphabet(DefaultAlphabet)}}
+	for _, enc := range encoders {
+		_, err := enc.Decode("zzzzzzzzzzzzzzzzzzzzzz")
+		if !errors.Is(err, ErrOverflow) {
+			t.Errorf("expected %v, got %v", ErrOverflow, err)
+		}
+	}
+}
+
+// lenientEncoder hides the DecodeStrict method of the encoder it wraps.
+type lenientEncoder struct {
+	Encoder
+}
+
+// checkDecode checks the properties every successful decode must have.
+func checkDecode(t *testing.T, enc AppendEncoder, s string) {
+	u, err := enc.Decode(s)
+	if v, berr := enc.DecodeBytes([]byte(s)); v != u || (berr == nil) != (err == nil) {
+		t.Errorf("expected DecodeBytes(%q) to return %v, %v, got %v, %v", s, u, err, v, berr)
+	}
+	if err != nil {
+		return
+	}
+	if v, err := enc.Decode(enc.Encode(u)); err != nil || v != u {
+		t.Errorf("expected %q to round-trip to %v, got %v, %v", s, u, v, err)
+	}
+	if v, err := enc.(StrictDecoder).DecodeStrict(s); err == nil {
+		if v != u {
+			t.Errorf("expected DecodeStrict(%q) to return %v, got %v", s, u, v)
+		}
+		if e := enc.Encode(v); e != s {
+			t.Errorf("expected DecodeStrict to only accept canonical input, got %q for %q", s, e)
+		}
+	}
+}
+
+func FuzzB57Decode(f *testing.F) {
+	for _, test := range testVector {
+		f.Add(test.shortuuid)
+	}
+	f.Add("")
+	f.Add("zzzzzzzzzzzzzzzzzzzzzz")
+	f.Add("1lIO022222222222222222")
+	f.Add("32222222222223")
+	generic := encoder{newAlphabet(DefaultAlphabet)}
+	f.Fuzz(func(t *testing.T, s string) {
+		checkDecode(t, DefaultEncoder, s)
+
+		// DefaultEncoder is an optimized version of the generic encoder, so
+		// the two must agree on every input, including the errors.
+		u, err := DefaultEncoder.Decode(s)
+		exp, expErr := generic.Decode(s)
+		if u != exp || !sameDecodeError(err, expErr) {
+			t.Errorf("expected %v, %v for %q, got %v, %v", exp, expErr, s, u, err)
+		}
+	})
+}
+
+// sameDecodeError reports whether err is the same kind of error as exp, i.e.
+// an equal *InvalidCharError or the same sentinel error.
+func sameDecodeError(err, exp error) bool {
+	var charErr, expCharErr *InvalidCharError
+	if errors.As(exp, &expCharErr) {
+		return errors.As(err, &charErr) && *charErr == *expCharErr
+	}
+	return errors.Is(err, exp)
+}
+
+func FuzzEncoderDecode(f *testing.F) {
+	encoders := []encoder{
+		{newAlphabet(DefaultAlphabet)},
+		{newAlphabet("0123456789abcdef")},
+		{newAlphabet("うえおなにぬねのウエオナニヌネノ")},
+	}
+	for _, test := range testVector {
+		f.Add(test.shortuuid)
+	}
+	f.Add("")
+	f.Add("ffffffffffffffffffffffffffffffff")
+	f.Add("うえaお")
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, enc := range encoders {
+			checkDecode(t, enc, s)
+		}
+	})
+}
+
 func TestNewWithAlphabet(t *testing.T) {
 	abc := DefaultAlphabet[:len(DefaultAlphabet)-1] + "="
 	enc := encoder{newAlphabet(abc)}
@@ -244,6 +631,36 @@ func TestNewWithAlphabet_Short(t *testing.T) {
 	}
 }
 
+func TestNewEncoder(t *testing.T) {
+	abc := DefaultAlphabet[:len(DefaultAlphabet)-1] + "="
+	enc, err := NewEncoder(abc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u1 := uuid.MustParse("e9ae9ba7-4fb1-4a6d-bbca-5315ed438371")
+	u2 := enc.Encode(u1)
+	if u2 != "iZsai==fWebXd5rLRWFB=u" {
+		t.Errorf("expected uuid to be %q, got %q", "iZsai==fWebXd5rLRWFB=u", u2)
+	}
+	u3, err := enc.Decode(u2)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if u1 != u3 {
+		t.Errorf("expected %q, got %q", u1, u3)
+	}
+}
+
+func TestNewEncoderInvalidAlphabet(t *testing.T) {
+	for _, abc := range []string{"", "a", "aaaa"} {
+		enc, err := NewEncoder(abc)
+		if err == nil {
+			t.Errorf("expected an error for alphabet %q, got %v", abc, enc)
+		}
+	}
+}
+
 func TestAlphabetCustomLen(t *testing.T) {
 	abc := "21345687654123456"
 	enc := encoder{newAlphabet(abc)}
@@ -264,6 +681,21 @@ func TestAlphabetCustomLen(t *testing.T) {
 	}
 }
 
+func TestAlphabetWideSpan(t *testing.T) {
+	enc := encoder{newAlphabet("0123456789abcdef😀")}
+	for _, test := range testVector[:20] {
+		u1 := uuid.MustParse(test.uuid)
+		u2, err := enc.Decode(enc.Encode(u1))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if u1 != u2 {
+			t.Errorf("expected %q, got %q", u1, u2)
+		}
+	}
+}
+
 func TestAlphabet_MB(t *testing.T) {
 	abc := "うえおなにぬねのウエオナニヌネノ"
 	enc := encoder{newAlphabet(abc)}
@@ -290,6 +722,12 @@ func BenchmarkUUID(b *testing.B) {
 	}
 }
 
+func BenchmarkRandom(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Random(22)
+	}
+}
+
 func BenchmarkEncoding(b *testing.B) {
 	u := uuid.New()
 	for i := 0; i < b.N; i++ {
@@ -297,6 +735,23 @@ func BenchmarkEncoding(b *testing.B) {
 	}
 }
 
+func BenchmarkAppendEncode(b *testing.B) {
+	u := uuid.New()
+	buf := make([]byte, 0, 22)
+	for i := 0; i < b.N; i++ {
+		buf = DefaultEncoder.AppendEncode(buf[:0], u)
+	}
+}
+
+func BenchmarkAppendEncodeB16(b *testing.B) {
+	u := uuid.New()
+	enc := encoder{alphabet: newAlphabet("0123456789abcdef")}
+	buf := make([]byte, 0, 32)
+	for i := 0; i < b.N; i++ {
+		buf = enc.AppendEncode(buf[:0], u)
+	}
+}
+
 func BenchmarkEncodingB57_MB(b *testing.B) {
 	u := uuid.New()
 	enc := encoder{alphabet: newAlphabet("23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghiうえおなにぬねのウエオナニヌネノ")}
@@ -327,6 +782,13 @@ func BenchmarkDecoding(b *testing.B) {
 	}
 }
 
+func BenchmarkDecodingB57(b *testing.B) {
+	enc := encoder{alphabet: newAlphabet(DefaultAlphabet)}
+	for i := 0; i < b.N; i++ {
+		_, _ = enc.Decode("nUfojcH2M5j9j3Tk5A8mf7")
+	}
+}
+
 func BenchmarkDecodingB16(b *testing.B) {
 	enc := encoder{alphabet: newAlphabet("0123456789abcdef")}
 	for i := 0; i < b.N; i++ {
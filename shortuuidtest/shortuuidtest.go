@@ -0,0 +1,121 @@
+// Package shortuuidtest implements a conformance suite for shortuuid.Encoder
+// implementations, to be used in their tests:
+//
+//	func TestMyEncoder(t *testing.T) {
+//		shortuuidtest.TestEncoder(t, myEncoder{})
+//	}
+package shortuuidtest
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/lithammer/shortuuid/v4"
+)
+
+// Option changes which properties TestEncoder checks.
+type Option func(*config)
+
+type config struct {
+	unordered bool
+}
+
+// Unordered disables the check that encoding preserves the order of UUIDs,
+// for encoders that intentionally don't, such as encoders that put the least
+// significant digit first.
+func Unordered() Option {
+	return func(c *config) { c.unordered = true }
+}
+
+// invalidChars are characters that no sensible alphabet contains, and that
+// the built-in normalizing and grouping encoders don't skip.
+var invalidChars = []string{"\x00", "\x7f", "\ufffd"}
+
+// numRandom is the number of random UUIDs to check.
+const numRandom = 100
+
+// TestEncoder checks that enc:
+//   - decodes the encoding of random UUIDs, the nil UUID and the max UUID back
+//     to the same UUID;
+//   - encodes every UUID to the same number of characters;
+//   - encodes UUIDs so that the encodings sort in the same order as the UUIDs,
+//     unless the Unordered option is given;
+//   - rejects encodings in which a character is replaced by, or followed by, a
+//     character that isn't part of any alphabet.
+//
+// The random UUIDs are the same on every run.
+func TestEncoder(t *testing.T, enc shortuuid.Encoder, opts ...Option) {
+	t.Helper()
+
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	uuids := []uuid.UUID{uuid.Nil, uuid.Max}
+	r := shortuuid.NewSeededReader(1)
+	for i := 0; i < numRandom; i++ {
+		uuids = append(uuids, uuid.Must(uuid.NewRandomFromReader(r)))
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		for _, u := range uuids {
+			s := enc.Encode(u)
+			got, err := enc.Decode(s)
+			if err != nil {
+				t.Errorf("expected %q (encoded from %s) to decode, got %v", s, u, err)
+				continue
+			}
+			if got != u {
+				t.Errorf("expected %q to decode to %s, got %s", s, u, got)
+			}
+		}
+	})
+
+	t.Run("FixedLength", func(t *testing.T) {
+		n := utf8.RuneCountInString(enc.Encode(uuid.Nil))
+		if n == 0 {
+			t.Fatalf("expected a non-empty encoding of %s", uuid.Nil)
+		}
+		for _, u := range uuids {
+			if s := enc.Encode(u); utf8.RuneCountInString(s) != n {
+				t.Errorf("expected %d characters, got %q (encoded from %s)", n, s, u)
+			}
+		}
+	})
+
+	t.Run("Ordering", func(t *testing.T) {
+		if c.unordered {
+			t.Skip("encoder is unordered")
+		}
+		sorted := slices.Clone(uuids)
+		slices.SortFunc(sorted, func(a, b uuid.UUID) int {
+			return bytes.Compare(a[:], b[:])
+		})
+		for i := 1; i < len(sorted); i++ {
+			a, b := enc.Encode(sorted[i-1]), enc.Encode(sorted[i])
+			if a >= b {
+				t.Errorf("expected %q < %q (encoded from %s and %s)", a, b, sorted[i-1], sorted[i])
+			}
+		}
+	})
+
+	t.Run("InvalidChars", func(t *testing.T) {
+		s := enc.Encode(uuids[len(uuids)-1])
+		for _, invalid := range invalidChars {
+			inputs := []string{s + invalid}
+			for i := range s {
+				_, size := utf8.DecodeRuneInString(s[i:])
+				inputs = append(inputs, s[:i]+invalid+s[i+size:])
+			}
+			for _, in := range inputs {
+				if u, err := enc.Decode(in); err == nil {
+					t.Errorf("expected an error for %q, got %s", in, u)
+				}
+			}
+		}
+	})
+}
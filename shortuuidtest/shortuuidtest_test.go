@@ -0,0 +1,54 @@
+package shortuuidtest
+
+import (
+	"testing"
+
+	"github.com/lithammer/shortuuid/v4"
+)
+
+func TestBuiltinEncoders(t *testing.T) {
+	mustEncoder := func(enc shortuuid.Encoder, err error) shortuuid.Encoder {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return enc
+	}
+
+	encoders := map[string]shortuuid.Encoder{
+		"Default":         shortuuid.DefaultEncoder,
+		"Base62":          shortuuid.Base62Encoder,
+		"Base58":          shortuuid.Base58Encoder,
+		"Base36":          shortuuid.Base36Encoder,
+		"Base64URL":       shortuuid.Base64URLEncoder,
+		"Base32Crockford": shortuuid.Base32CrockfordEncoder,
+		"Hex":             mustEncoder(shortuuid.NewEncoder("0123456789abcdef")),
+		"Binary":          mustEncoder(shortuuid.NewEncoder("01")),
+		"Multibyte":       mustEncoder(shortuuid.NewEncoder("うえおなにぬねのウエオナニヌネノ")),
+		"Check":           mustEncoder(shortuuid.NewCheckEncoder(shortuuid.DefaultEncoder)),
+		"Prefix":          mustEncoder(shortuuid.NewPrefixEncoder("user", shortuuid.DefaultEncoder)),
+		"Grouping":        mustEncoder(shortuuid.NewGroupingEncoder(shortuuid.DefaultEncoder, 4, "-")),
+		"Signing": mustEncoder(shortuuid.NewSigningEncoder(shortuuid.DefaultEncoder, 11, shortuuid.SigningKey{
+			ID:     'A',
+			Secret: []byte("0123456789abcdef0123456789abcdef"),
+		})),
+		"Normalizing": mustEncoder(shortuuid.NewNormalizingEncoder(shortuuid.Base36Encoder, shortuuid.Normalization{
+			Skip:      "-",
+			SkipSpace: true,
+		})),
+	}
+	for name, enc := range encoders {
+		t.Run(name, func(t *testing.T) {
+			TestEncoder(t, enc)
+		})
+	}
+
+	t.Run("LegacyPython", func(t *testing.T) {
+		TestEncoder(t, shortuuid.LegacyPythonEncoder, Unordered())
+	})
+
+	t.Run("Encrypting", func(t *testing.T) {
+		key := []byte("0123456789abcdef")
+		TestEncoder(t, mustEncoder(shortuuid.NewEncryptingEncoder(shortuuid.DefaultEncoder, key)), Unordered())
+	})
+}
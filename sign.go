@@ -0,0 +1,150 @@
+package shortuuid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// minSigningKeyLen is the minimum length of a signing key in bytes.
+const minSigningKeyLen = 16
+
+// SigningKey is a key used by the encoders returned by NewSigningEncoder.
+type SigningKey struct {
+	// ID identifies the key in signed IDs. It must be part of the alphabet
+	// of the wrapped encoder.
+	ID rune
+
+	// Secret is the HMAC key. It must be at least 16 bytes long, and should
+	// be 32 random bytes.
+	Secret []byte
+}
+
+// signingEncoder wraps an alphabet-based encoder and appends a key ID and an
+// HMAC tag to its output.
+type signingEncoder struct {
+	enc      alphabetEncoder
+	alphabet *alphabet
+	tagLen   int
+	signer   SigningKey
+	keys     map[rune][]byte
+}
+
+// NewSigningEncoder returns an Encoder that appends the ID of a key and a tag
+// of tagLen characters to the output of enc, and rejects IDs with a missing or
+// wrong tag when decoding. The tag is a truncated HMAC-SHA256 of the rest of
+// the ID, encoded in the alphabet of enc. enc must be built on an alphabet:
+// DefaultEncoder, Base62Encoder, Base58Encoder, Base36Encoder,
+// Base64URLEncoder, LegacyPythonEncoder, or an encoder returned by NewEncoder
+// or NewLegacyPythonEncoder.
+//
+// IDs are signed with the first of keys, and verified with whichever key they
+// name, which allows keys to be rotated: add a new key in front, and remove
+// the old one once the IDs signed with it have expired. Decode verifies the
+// tag in constant time and returns ErrSignature if the check fails.
+//
+// Each tag character adds log2(len(alphabet)) bits of security, e.g. a tag of
+// 11 base57 characters gives 64 bits. tagLen must be at most the length of an
+// encoded UUID.
+func NewSigningEncoder(enc Encoder, tagLen int, keys ...SigningKey) (Encoder, error) {
+	ae, ok := enc.(alphabetEncoder)
+	if !ok {
+		return nil, fmt.Errorf("unsupported encoder %T (must be based on an alphabet)", enc)
+	}
+	abc := ae.alpha()
+	if tagLen < 1 || tagLen > int(abc.encLen) {
+		return nil, fmt.Errorf("invalid tag length %d (must be between 1 and %d)", tagLen, abc.encLen)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("at least one signing key is required")
+	}
+
+	m := make(map[rune][]byte, len(keys))
+	for _, k := range keys {
+		if _, err := abc.Index(k.ID); err != nil {
+			return nil, fmt.Errorf("key ID %q is not part of the alphabet", k.ID)
+		}
+		if _, ok := m[k.ID]; ok {
+			return nil, fmt.Errorf("duplicate key ID %q", k.ID)
+		}
+		if len(k.Secret) < minSigningKeyLen {
+			return nil, fmt.Errorf("signing key %q is too short (must be at least %d bytes)", k.ID, minSigningKeyLen)
+		}
+		m[k.ID] = append([]byte(nil), k.Secret...)
+	}
+	signer := SigningKey{ID: keys[0].ID, Secret: m[keys[0].ID]}
+	return signingEncoder{enc: ae, alphabet: abc, tagLen: tagLen, signer: signer, keys: m}, nil
+}
+
+func (e signingEncoder) Encode(u uuid.UUID) string {
+	s := utf8.AppendRune([]byte(e.enc.Encode(u)), e.signer.ID)
+	return string(e.appendTag(s, e.signer.Secret, s))
+}
+
+func (e signingEncoder) Decode(s string) (uuid.UUID, error) {
+	payload, err := e.verify(s)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	return e.enc.Decode(payload)
+}
+
+// DecodeStrict implements StrictDecoder.
+func (e signingEncoder) DecodeStrict(s string) (uuid.UUID, error) {
+	payload, err := e.verify(s)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	return decodeStrict(e.enc, payload)
+}
+
+// verify checks the tag at the end of s, and returns the part of s before the
+// key ID, i.e. the output of the wrapped encoder.
+func (e signingEncoder) verify(s string) (string, error) {
+	// Find the start of the tag and the key ID in front of it.
+	i := len(s)
+	for n := 0; n < e.tagLen; n++ {
+		_, size := utf8.DecodeLastRuneInString(s[:i])
+		if size == 0 {
+			return "", fmt.Errorf("%w: missing signature", ErrLength)
+		}
+		i -= size
+	}
+	id, size := utf8.DecodeLastRuneInString(s[:i])
+	if size == 0 {
+		return "", fmt.Errorf("%w: missing key ID", ErrLength)
+	}
+
+	key, ok := e.keys[id]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown key ID %q", ErrSignature, id)
+	}
+	var buf [128 * utf8.UTFMax]byte
+	tag := e.appendTag(buf[:0], key, []byte(s[:i]))
+	if subtle.ConstantTimeCompare(tag, []byte(s[i:])) != 1 {
+		return "", ErrSignature
+	}
+	return s[:i-size], nil
+}
+
+// appendTag appends the tag for msg signed with key to dst.
+func (e signingEncoder) appendTag(dst, key, msg []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(msg)
+	var sum [sha256.Size]byte
+	mac.Sum(sum[:0])
+
+	num := uint128{binary.BigEndian.Uint64(sum[8:16]), binary.BigEndian.Uint64(sum[:8])}
+	var r uint64
+	for i := 0; i < e.tagLen; i++ {
+		num, r = num.quoRem64(uint64(e.alphabet.len))
+		dst = utf8.AppendRune(dst, e.alphabet.chars[r])
+	}
+	return dst
+}
@@ -0,0 +1,143 @@
+package shortuuid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+var (
+	testSigningKeyA = SigningKey{ID: 'A', Secret: []byte("0123456789abcdef0123456789abcdef")}
+	testSigningKeyB = SigningKey{ID: 'B', Secret: []byte("fedcba9876543210fedcba9876543210")}
+)
+
+func TestSigningEncoder(t *testing.T) {
+	// Computed with Python's hmac module.
+	tests := []struct {
+		key    SigningKey
+		tagLen int
+		uuid   string
+		signed string
+	}{
+		{testSigningKeyA, 11, "00000000-0000-0000-0000-000000000000", "2222222222222222222222AKFQfqsiaB3f"},
+		{testSigningKeyB, 6, "64d1355f-d052-4bd9-83f4-39b93fb1c01f", "KwSysDpxcBU9FNhGkn2dCfBz4Gq4D"},
+	}
+	for _, test := range tests {
+		enc, err := NewSigningEncoder(DefaultEncoder, test.tagLen, test.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u := uuid.MustParse(test.uuid)
+		if s := enc.Encode(u); s != test.signed {
+			t.Errorf("expected %q, got %q", test.signed, s)
+		}
+		got, err := enc.Decode(test.signed)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if got != u {
+			t.Errorf("expected %q, got %q", u, got)
+		}
+	}
+}
+
+func TestSigningEncoderTampering(t *testing.T) {
+	enc, _ := NewSigningEncoder(DefaultEncoder, 11, testSigningKeyA)
+	s := enc.Encode(uuid.MustParse("64d1355f-d052-4bd9-83f4-39b93fb1c01f"))
+
+	for i := range s {
+		for _, c := range DefaultAlphabet {
+			if rune(s[i]) == c {
+				continue
+			}
+			changed := s[:i] + string(c) + s[i+1:]
+			if _, err := enc.Decode(changed); !errors.Is(err, ErrSignature) {
+				t.Fatalf("expected %v for %q, got %v", ErrSignature, changed, err)
+			}
+		}
+	}
+
+	tests := []struct {
+		s   string
+		err error
+	}{
+		{"", ErrLength},
+		{"KwSysDpxcB", ErrLength},
+		{s[:len(s)-1], ErrSignature},
+		{"2" + s, ErrSignature},
+		{s + "2", ErrSignature},
+	}
+	for _, test := range tests {
+		if _, err := enc.Decode(test.s); !errors.Is(err, test.err) {
+			t.Errorf("expected %v for %q, got %v", test.err, test.s, err)
+		}
+	}
+}
+
+func TestSigningEncoderKeyRotation(t *testing.T) {
+	oldEnc, _ := NewSigningEncoder(DefaultEncoder, 11, testSigningKeyA)
+	newEnc, _ := NewSigningEncoder(DefaultEncoder, 11, testSigningKeyB, testSigningKeyA)
+	u := uuid.New()
+
+	oldID, newID := oldEnc.Encode(u), newEnc.Encode(u)
+	if oldID == newID {
+		t.Errorf("expected IDs signed with different keys to differ, got %q", oldID)
+	}
+	for _, s := range []string{oldID, newID} {
+		got, err := newEnc.(StrictDecoder).DecodeStrict(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != u {
+			t.Errorf("expected %q, got %q", u, got)
+		}
+	}
+	if _, err := oldEnc.Decode(newID); !errors.Is(err, ErrSignature) {
+		t.Errorf("expected %v for an unknown key, got %v", ErrSignature, err)
+	}
+
+	// An ID can't be moved to another key by changing the key ID.
+	forged := oldID[:22] + "B" + oldID[23:]
+	if _, err := newEnc.Decode(forged); !errors.Is(err, ErrSignature) {
+		t.Errorf("expected %v for %q, got %v", ErrSignature, forged, err)
+	}
+}
+
+func TestSigningEncoderMultibyte(t *testing.T) {
+	base, _ := NewEncoder("うえおなにぬねのウエオナニヌネノ")
+	enc, err := NewSigningEncoder(base, 8, SigningKey{ID: 'ウ', Secret: testSigningKeyA.Secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := uuid.New()
+	got, err := enc.Decode(enc.Encode(u))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != u {
+		t.Errorf("expected %q, got %q", u, got)
+	}
+}
+
+func TestNewSigningEncoderErrors(t *testing.T) {
+	tests := []struct {
+		enc    Encoder
+		tagLen int
+		keys   []SigningKey
+	}{
+		{Base32CrockfordEncoder, 11, []SigningKey{testSigningKeyA}},
+		{DefaultEncoder, 0, []SigningKey{testSigningKeyA}},
+		{DefaultEncoder, 23, []SigningKey{testSigningKeyA}},
+		{DefaultEncoder, 11, nil},
+		{DefaultEncoder, 11, []SigningKey{{ID: '0', Secret: testSigningKeyA.Secret}}},
+		{DefaultEncoder, 11, []SigningKey{testSigningKeyA, testSigningKeyA}},
+		{DefaultEncoder, 11, []SigningKey{{ID: 'A', Secret: []byte("short")}}},
+	}
+	for _, test := range tests {
+		if _, err := NewSigningEncoder(test.enc, test.tagLen, test.keys...); err == nil {
+			t.Errorf("expected an error for %T with tag length %d and keys %v", test.enc, test.tagLen, test.keys)
+		}
+	}
+}